@@ -95,6 +95,64 @@ import (
 // or a string type.
 type ID = string
 
+// ConnectionState is the state of a session's connection to the service.
+type ConnectionState uint8
+
+const (
+	// Connecting means that the session is connecting for the first time.
+	ConnectionStateConnecting ConnectionState = iota
+	// Connected means that the session is usable.
+	ConnectionStateConnected
+	// Reconnecting means that the session has lost its connection and is trying
+	// to connect again.
+	ConnectionStateReconnecting
+	// Disconnected means that the session has lost its connection and will not
+	// reconnect by itself.
+	ConnectionStateDisconnected
+)
+
+func (c ConnectionState) Is(is ConnectionState) bool {
+	return c == is
+}
+
+// NotificationLevel is the level of messages that the user wants to be notified
+// of in a server.
+type NotificationLevel uint8
+
+const (
+	// Default means that the server has no preference, and the frontend should
+	// use its own default.
+	NotificationLevelDefault NotificationLevel = iota
+	// All notifies on all messages.
+	NotificationLevelAll
+	// Mentions notifies only on messages that mention the user.
+	NotificationLevelMentions
+	// None never notifies.
+	NotificationLevelNone
+)
+
+func (n NotificationLevel) Is(is NotificationLevel) bool {
+	return n == is
+}
+
+// SendState is the state of an outgoing message, which is reported to a
+// SendStateContainer.
+type SendState uint8
+
+const (
+	// Pending means that the message is being sent or is waiting to be retried.
+	SendStatePending SendState = iota
+	// Sent means that the message is sent.
+	SendStateSent
+	// Failed means that the message could not be sent. The frontend may ask the
+	// user to retry.
+	SendStateFailed
+)
+
+func (s SendState) Is(is SendState) bool {
+	return s == is
+}
+
 // Status represents a user's status. This might be used by the frontend to
 // visually display the status.
 type Status uint8
@@ -114,6 +172,20 @@ func (s Status) Is(is Status) bool {
 	return s == is
 }
 
+// ActionDescriptor describes a single message action in more detail than a
+// plain string. It is returned by ActionDescriber, and frontends can use it to
+// display icons, group actions together or ask the user for confirmation or
+// input before running the action.
+type ActionDescriptor struct {
+	ID          string
+	Label       text.Rich
+	Group       string
+	IconURL     string
+	Destructive bool
+	Confirm     bool
+	Form        []AuthenticateEntry
+}
+
 // AuthenticateEntry represents a single authentication entry, usually an email
 // or password prompt. Passwords or similar entries should have Secrets set to
 // true, which should imply to frontends that the fields be masked.
@@ -135,12 +207,55 @@ type CompletionEntry struct {
 	Image     bool
 }
 
+// Draft is an unsent message in the input box of a server that is synchronized
+// by a DraftSyncer.
+type Draft struct {
+	Content    string
+	ReplyingTo ID
+}
+
+// Emoji is a single emoji or sticker in an EmojiGroup.
+type Emoji struct {
+	Name      string
+	Shortcode string
+	ImageURL  string
+	Animated  bool
+	Sticker   bool
+}
+
+// EmojiGroup is a group of emojis, such as the emojis of a single guild or a
+// Unicode category.
+type EmojiGroup struct {
+	Name    string
+	IconURL string
+	Emojis  []Emoji
+}
+
 // MessageAttachment represents a single file attachment. If needed, the
 // frontend will close the reader after the message is sent, that is when the
 // SendMessage function returns. The backend must not use the reader after that.
 type MessageAttachment struct {
 	io.Reader
-	Name string
+	Name     string
+	Size     int64
+	MIMEType string
+}
+
+// NotificationPreference is the notification preference of a single server. It
+// is used by NotificationSettings.
+type NotificationPreference struct {
+	Level            NotificationLevel
+	MutedUntil       time.Time
+	SuppressEveryone bool
+}
+
+// Presence represents the presence of the current user. It is used both for
+// setting the user's own presence with PresenceSetter and for receiving it in a
+// PresenceContainer.
+type Presence struct {
+	Status       Status
+	CustomStatus text.Rich
+	Expiry       time.Time
 }
 
 // ReadIndication represents a read indication of a user/author in a messager
@@ -154,6 +269,13 @@ type ReadIndication struct {
 	MessageID ID
 }
 
+// Role represents a single role of a user in a server, such as one displayed in
+// a ProfileContainer.
+type Role struct {
+	ID   ID
+	Name text.Rich
+}
+
 // ErrInvalidConfigAtField is the structure for an error at a specific
 // configuration field. Frontends can use this and highlight fields if the
 // backends support it.
@@ -170,6 +292,20 @@ func (e ErrInvalidConfigAtField) Unwrap() error {
 	return e.Err
 }
 
+// ActionDescriber extends Actioner to describe each action in detail using
+// ActionDescriptor instead of a bare string. The string API in Actioner must
+// still be implemented for compatibility; the descriptors only complement them.
+type ActionDescriber interface {
+	// DoInput executes a message action that has a non-empty Form on the given
+	// message ID. The values have indices that correspond to the Form slice. This
+	// method is allowed to do IO.
+	DoInput(ctx context.Context, action string, id ID, values []string) error // Blocking
+	// DescribeActions returns a list of action descriptors for the message with the
+	// given ID. The order and IDs of the returned descriptors should match the
+	// strings returned by Actions. This method must not do IO.
+	DescribeActions(id ID) []ActionDescriptor
+}
+
 // Actioner adds custom message actions into each message. Similarly to
 // ServerMessageEditor, some of these methods may do IO.
 type Actioner interface {
@@ -183,11 +319,19 @@ type Actioner interface {
 	//
 	// The string slice returned can be nil or empty.
 	Actions(id ID) []string
+
+	// Asserters.
+
+	AsActionDescriber() ActionDescriber // Optional
 }
 
 // Attacher adds attachments into the message being sent.
 type Attacher interface {
 	Attachments() []MessageAttachment
+
+	// Asserters.
+
+	AsUploadProgressContainer() UploadProgressContainer // Optional
 }
 
 // AuthenticateError is the error returned when authenticating. This error
@@ -324,6 +468,71 @@ type Configurator interface {
 	Configuration() map[string]string
 }
 
+// ConnectionStateContainer is a frontend container that displays the connection
+// state of a session.
+type ConnectionStateContainer interface {
+	// SetLatency sets the latest measured round-trip time to the service. Backends
+	// that cannot measure the latency never call this method.
+	SetLatency(ctx context.Context, latency time.Duration)
+	// SetConnectionState sets the connection state. The error is the optional
+	// reason of the state, such as why the connection was lost.
+	SetConnectionState(ctx context.Context, state ConnectionState, err error)
+}
+
+// ConnectionStater extends Session to report the state of its connection, so
+// that frontends can show a banner and disable sending while the session is not
+// connected.
+type ConnectionStater interface {
+	// ConnectionSubscribe subscribes the given container to the session's
+	// connection state. The backend should call SetConnectionState with the current
+	// state right away, and then on every transition until the stop callback is
+	// called.
+	ConnectionSubscribe(context.Context, ConnectionStateContainer) (stop func(), err error)
+}
+
+// Deleter adds message deleting to the messenger. Only Delete can do IO.
+//
+// Deleting is not limited to the user's own messages: backends may also allow
+// moderators to delete others' messages, in which case IsDeletable should
+// return true for those as well. The backend should still send a DeleteMessage
+// event to the MessagesContainer once the message is actually deleted.
+type Deleter interface {
+	// Delete deletes the message with the given ID. This method can do IO.
+	Delete(ctx context.Context, id ID) error // Blocking
+	// IsDeletable returns whether or not a message can be deleted by the client.
+	// This method must not do IO.
+	IsDeletable(id ID) bool
+}
+
+// DirectMessager extends Session to allow starting a private conversation with
+// other users, such as when a member in the member list is clicked. It can also
+// be asserted from ListMember, in which case the backend should return the
+// DirectMessager of the session that the member belongs to.
+type DirectMessager interface {
+	// DirectMessage opens the direct message server with the users with the given
+	// IDs, creating it if it does not exist yet. A single ID opens a one-on-one
+	// conversation, while multiple IDs open a group conversation if the backend
+	// supports it.
+	//
+	// The returned server should implement Messenger. The backend should also add
+	// it to the session's server list if it is not already there, but the frontend
+	// must not rely on that. This method can do IO.
+	DirectMessage(ctx context.Context, userIDs []ID) (Server, error) // Blocking
+}
+
+// DraftSyncer extends Messenger for services that synchronize unsent messages
+// across devices. Frontends should still keep their own drafts locally, such as
+// with the drafts package inside utils, and use DraftSyncer on top of that.
+type DraftSyncer interface {
+	// SetDraft sets the draft of the server. An empty Draft clears it. The frontend
+	// should throttle calls to this method, such as by only calling it when the
+	// user switches away from the server. This method can do IO.
+	SetDraft(ctx context.Context, draft Draft) error // Blocking
+	// Draft returns the draft of the server. An empty Draft is returned if there is
+	// none. This method can do IO.
+	Draft(context.Context) (Draft, error) // Blocking
+}
+
 // Editor adds message editing to the messenger. Only EditMessage can do IO.
 type Editor interface {
 	// Edit edits the message with the given ID to the given content, which is the
@@ -337,6 +546,19 @@ type Editor interface {
 	IsEditable(id ID) bool
 }
 
+// Emojier adds an emoji and sticker catalog for frontends to show in a picker.
+// It can be asserted from both Session and Messenger: the one from Session
+// should return the emojis usable anywhere, while the one from Messenger should
+// return the emojis usable in that server.
+//
+// Backends that implement Emojier should also use the emoji package inside
+// utils for their Completer, so that completing ":shortcode" behaves the same
+// across all backends.
+type Emojier interface {
+	// Emojis returns the list of emoji groups. This method can do IO.
+	Emojis(context.Context) ([]EmojiGroup, error) // Blocking
+}
+
 // Identifier requires ID() to return a uniquely identifiable string for
 // whatever this is embedded into. Typically, servers and messages have IDs. It
 // is worth mentioning that IDs should be consistent throughout the lifespan of
@@ -377,6 +599,10 @@ type ListMember interface {
 	// Name returns the username or the nickname of the member, whichever the
 	// backend should prefer.
 	Name() text.Rich
+
+	// Asserters.
+
+	AsDirectMessager() DirectMessager // Optional
 }
 
 // Lister is for servers that contain children servers. This is similar to
@@ -406,6 +632,15 @@ type Lister interface {
 	Columnate() bool
 }
 
+// MassMentioner extends MessageCreate for messages that can mention everyone,
+// such as with @everyone or @here. Frontends use it to apply
+// NotificationPreference's SuppressEveryone.
+type MassMentioner interface {
+	// MassMentioned returns true if the message only mentions the current user by
+	// mentioning everyone. It returns false if the user is also mentioned directly.
+	MassMentioned() bool
+}
+
 // MemberDynamicSection represents a dynamically loaded member list section. The
 // section behaves similarly to MemberSection, except the information displayed
 // will be considered incomplete until LoadMore returns false.
@@ -506,6 +741,10 @@ type MessageCreate interface {
 	Mentioned() bool
 	Content() text.Rich
 	Author() User
+
+	// Asserters.
+
+	AsMassMentioner() MassMentioner // Optional
 }
 
 // MessageDelete is the interface for a message delete event.
@@ -563,12 +802,18 @@ type Messenger interface {
 
 	AsSender() Sender                   // Optional
 	AsEditor() Editor                   // Optional
+	AsDeleter() Deleter                 // Optional
 	AsActioner() Actioner               // Optional
 	AsNicknamer() Nicknamer             // Optional
 	AsBacklogger() Backlogger           // Optional
 	AsMemberLister() MemberLister       // Optional
+	AsReadIndicator() ReadIndicator     // Optional
 	AsUnreadIndicator() UnreadIndicator // Optional
 	AsTypingIndicator() TypingIndicator // Optional
+	AsProfiler() Profiler               // Optional
+	AsPinner() Pinner                   // Optional
+	AsEmojier() Emojier                 // Optional
+	AsDraftSyncer() DraftSyncer         // Optional
 }
 
 // Namer requires Name() to return the name of the object. Typically, this
@@ -610,6 +855,108 @@ type Noncer interface {
 	Nonce() string
 }
 
+// NotificationSettings extends Server to expose the user's notification
+// preference for that server. Frontends should consult it before notifying the
+// user of new messages or unread events; the notify package inside utils
+// implements this policy.
+//
+// SuppressEveryone is applied by the frontend to messages that implement
+// MassMentioner. Since unread events cannot tell mass mentions apart, the
+// backend must apply it to the UnreadContainer itself: if it is true, then such
+// mentions must not be reported as mentions there.
+type NotificationSettings interface {
+	// SetNotificationPreference sets the notification preference of the server,
+	// such as when the user mutes it. This method can do IO to synchronize the
+	// preference with other clients.
+	SetNotificationPreference(ctx context.Context, pref NotificationPreference) error // Blocking
+	// NotificationPreference returns the current notification preference of the
+	// server. This method must not do IO.
+	NotificationPreference() NotificationPreference
+}
+
+// Pinner adds pinned messages to the messenger. Only Pin, Unpin and Pins can do
+// IO.
+type Pinner interface {
+	// Pins lists the pinned messages of the server into the given
+	// MessagesContainer, which is usually a separate view from the one given to
+	// JoinServer. The backend should call CreateMessage for each pinned message,
+	// UpdateMessage when a pinned message is edited and DeleteMessage when a
+	// message is unpinned or deleted, until the stop callback is called.
+	Pins(context.Context, MessagesContainer) (stop func(), err error)
+	// Unpin unpins the message with the given ID. This method can do IO.
+	Unpin(ctx context.Context, id ID) error // Blocking
+	// Pin pins the message with the given ID. This method can do IO.
+	Pin(ctx context.Context, id ID) error // Blocking
+	// IsPinnable returns whether or not the client can pin or unpin the message
+	// with the given ID. This method must not do IO.
+	IsPinnable(id ID) bool
+}
+
+// PresenceContainer is a frontend container that displays the current user's
+// presence, such as a status indicator next to the session's name.
+type PresenceContainer interface {
+	// SetPresence sets the current user's presence to the given one, replacing the
+	// old presence entirely.
+	SetPresence(context.Context, Presence)
+}
+
+// PresenceSetter extends Session to allow the current user to change their own
+// presence, that is their status and optional custom status.
+//
+// Since the presence can also be changed from other clients, the frontend
+// should subscribe to PresenceSubscribe instead of assuming that SetPresence is
+// the only source of changes.
+type PresenceSetter interface {
+	// PresenceSubscribe subscribes the given container to the current user's
+	// presence changes, including the ones made from other clients. The backend
+	// should call SetPresence on the container with the current presence right away
+	// if it knows it.
+	PresenceSubscribe(context.Context, PresenceContainer) (stop func(), err error)
+	// SetPresence sets the current user's presence. The backend should update any
+	// subscribed PresenceContainer once the presence is changed. This method can do
+	// IO.
+	SetPresence(ctx context.Context, presence Presence) error // Blocking
+}
+
+// ProfileContainer is a frontend container that displays a single user's
+// profile, such as a user card or a popover. Fields that are never set by the
+// backend should be treated as unavailable and hidden.
+type ProfileContainer interface {
+	// SetMutualServers sets the list of servers that both the current user and the
+	// user are in. The frontend may allow switching to these servers.
+	SetMutualServers(ctx context.Context, servers []Server)
+	// SetRoles sets the user's roles in the server.
+	SetRoles(ctx context.Context, roles []Role)
+	// SetStatus sets the user's status and the optional custom status text.
+	SetStatus(ctx context.Context, status Status, custom text.Rich)
+	// SetBio sets the user's biography or "about me" text.
+	SetBio(context.Context, text.Rich)
+	// SetDisplayName sets the user's display name, which may be the nickname in the
+	// server if the Profiler is from a Messenger.
+	SetDisplayName(context.Context, text.Rich)
+	// SetAvatar sets the URL to the user's avatar.
+	SetAvatar(ctx context.Context, url string)
+}
+
+// Profiler adds user profile lookups, which frontends can use to render user
+// cards, such as when a mention or a member in the member list is clicked.
+//
+// Profiler can be asserted from both Session and Messenger. The one from
+// Messenger should fill in the server-specific information, such as the
+// nickname and roles in that server, while the one from Session should only
+// fill in the global information.
+type Profiler interface {
+	// Profile fetches the profile of the user with the given ID into the
+	// ProfileContainer. The backend may call the container's methods in any order
+	// and any number of times, so the frontend can display the profile
+	// progressively, but all calls must be done before Profile returns. The
+	// frontend may discard the container once Profile returns.
+	//
+	// This method is technically a ContainerMethod, but is listed as an IOMethod
+	// because of the additional user ID parameter.
+	Profile(ctx context.Context, userID ID, profilec ProfileContainer) error // Blocking
+}
+
 // ReadContainer is an interface that a frontend container can implement to show
 // the read bubbles on messages. This container typically implies the message
 // container, but that is up to the frontend's implementation.
@@ -642,6 +989,30 @@ type Replier interface {
 	ReplyingTo() ID
 }
 
+// SendStateContainer is a frontend container that displays the state of
+// outgoing messages, such as by dimming pending messages and showing a retry
+// button on failed ones.
+type SendStateContainer interface {
+	// SetSendState sets the state of the message with the given nonce. The error is
+	// non-nil if the state is failed, and it may also be non-nil for a pending
+	// message that is being retried after an error.
+	SetSendState(ctx context.Context, nonce string, state SendState, err error)
+}
+
+// SendStateIndicator extends Sender to report the state of sent messages after
+// Send has returned. This is useful for backends that send messages
+// asynchronously, where a message may still fail after Send returns.
+//
+// States are keyed by the nonce of the SendableMessage, so only messages that
+// implement Noncer can be reported. The sendqueue package inside utils provides
+// a queue that retries failed messages.
+type SendStateIndicator interface {
+	// SendStateIndicate subscribes the given container to the states of messages
+	// sent from this Sender. The backend must stop calling the container once the
+	// stop callback is called.
+	SendStateIndicate(context.Context, SendStateContainer) (stop func(), err error)
+}
+
 // SendableMessage is the bare minimum interface of a sendable message, that is,
 // a message that can be sent with SendMessage(). This allows the frontend to
 // implement its own message data implementation.
@@ -670,7 +1041,8 @@ type Sender interface {
 
 	// Asserters.
 
-	AsCompleter() Completer // Optional
+	AsCompleter() Completer                   // Optional
+	AsSendStateIndicator() SendStateIndicator // Optional
 }
 
 // Server is a single server-like entity that could translate to a guild, a
@@ -685,10 +1057,11 @@ type Server interface {
 
 	// Asserters.
 
-	AsLister() Lister             // Optional
-	AsMessenger() Messenger       // Optional
-	AsCommander() Commander       // Optional
-	AsConfigurator() Configurator // Optional
+	AsLister() Lister                             // Optional
+	AsMessenger() Messenger                       // Optional
+	AsCommander() Commander                       // Optional
+	AsConfigurator() Configurator                 // Optional
+	AsNotificationSettings() NotificationSettings // Optional
 }
 
 // ServerUpdate represents a server update event.
@@ -798,8 +1171,13 @@ type Session interface {
 
 	// Asserters.
 
-	AsCommander() Commander       // Optional
-	AsSessionSaver() SessionSaver // Optional
+	AsCommander() Commander               // Optional
+	AsSessionSaver() SessionSaver         // Optional
+	AsPresenceSetter() PresenceSetter     // Optional
+	AsProfiler() Profiler                 // Optional
+	AsDirectMessager() DirectMessager     // Optional
+	AsEmojier() Emojier                   // Optional
+	AsConnectionStater() ConnectionStater // Optional
 }
 
 // SessionRestorer extends Service and is called by the frontend to restore a
@@ -886,6 +1264,25 @@ type UnreadContainer interface {
 	// SetUnread sets the container's unread state to the given boolean. The
 	// frontend may choose how to represent this.
 	SetUnread(ctx context.Context, unread bool, mentioned bool)
+
+	// Asserters.
+
+	AsUnreadCountContainer() UnreadCountContainer // Optional
+}
+
+// UnreadCountContainer extends UnreadContainer to show the number of unread
+// messages and mentions, such as in a badge. The backend must still call
+// SetUnread on the UnreadContainer, as frontends may not implement this
+// interface.
+//
+// Similarly to UnreadContainer, the frontend is expected to roll the counts up
+// to the parent nodes; the unread package inside utils provides a helper for
+// this.
+type UnreadCountContainer interface {
+	// SetUnreadCount sets the container's number of unread messages and mentions.
+	// Both counts being zero means that the server is read. The mention count
+	// should be less than or equal to the unread count.
+	SetUnreadCount(ctx context.Context, unread int, mentions int)
 }
 
 // UnreadIndicator adds an unread state API for frontends to use. The unread
@@ -911,6 +1308,22 @@ type UnreadIndicator interface {
 	MarkRead(ctx context.Context, messageID ID)
 }
 
+// UploadProgressContainer is a frontend container that displays the upload
+// progress of a message's attachments. It is asserted from the Attacher of the
+// message being sent, so the progress belongs to that message and its nonce, if
+// any.
+//
+// The backend should drive the container during Send, and it should stop using
+// it once Send returns. To cancel an upload, the frontend cancels the context
+// given to Send; the progress package inside utils provides a reader wrapper
+// that reports progress and stops reading once the context is cancelled.
+type UploadProgressContainer interface {
+	// SetUploadProgress sets the number of bytes sent of the attachment at the
+	// given index of Attachments. The total is the attachment's size, or 0 if it is
+	// unknown.
+	SetUploadProgress(ctx context.Context, index int, sent int64, total int64)
+}
+
 // User is the interface for an identifiable author. The interface defines that
 // an author always have an ID and a name.
 //
@@ -0,0 +1,235 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/dave/jennifer/jen"
+	"github.com/diamondburned/cchat/cmd/internal/cchat-generator/genutils"
+	"github.com/diamondburned/cchat/repository"
+)
+
+func init() {
+	log.SetFlags(0)
+}
+
+// iface is an interface along with its package path and all of its asserters,
+// including the ones from embedded interfaces.
+type iface struct {
+	Path      string
+	Name      string
+	Asserters []repository.InterfaceRef
+}
+
+func main() {
+	var ifaces = collect()
+
+	gen := genutils.NewFile("caps")
+	for pkgPath := range repository.Main {
+		gen.ImportName(pkgPath, path.Base(pkgPath))
+	}
+
+	var inspected []iface
+
+	for _, iface := range ifaces {
+		gen.Add(genCapFunc(iface))
+		gen.Line()
+
+		if len(iface.Asserters) > 0 {
+			inspected = append(inspected, iface)
+		}
+	}
+
+	gen.Comment("Inspect returns the capabilities of every interface with asserters that")
+	gen.Comment("v implements. Nil is returned if v implements none of them.")
+	gen.Func().Id("Inspect").Params(jen.Id("v").Interface()).Index().Id("Capability").BlockFunc(
+		func(g *jen.Group) {
+			g.Var().Id("caps").Index().Id("Capability")
+
+			for _, iface := range inspected {
+				g.If(
+					jen.List(jen.Id("v"), jen.Id("ok")).Op(":=").
+						Id("v").Assert(jen.Qual(iface.Path, iface.Name)),
+					jen.Id("ok"),
+				).Block(
+					jen.Id("caps").Op("=").Append(
+						jen.Id("caps"),
+						jen.Id(funcName(iface.Path, iface.Name)).Call(jen.Id("v")),
+					),
+				)
+			}
+
+			g.Return(jen.Id("caps"))
+		},
+	)
+
+	f, err := os.Create(filepath.Join(os.Args[1], "caps.go"))
+	if err != nil {
+		log.Fatalln("Failed to create output file:", err)
+	}
+	defer f.Close()
+
+	if err := gen.Render(f); err != nil {
+		log.Fatalln("Failed to render output:", err)
+	}
+}
+
+// collect collects all interfaces that either have asserters or are asserted
+// into, sorted with the root package first. It exits if the asserters form a
+// cycle, since inspecting them would never end.
+func collect() []iface {
+	var paths = []string{repository.RootPath}
+	for pkgPath := range repository.Main {
+		if pkgPath != repository.RootPath {
+			paths = append(paths, pkgPath)
+		}
+	}
+	sort.Strings(paths[1:])
+
+	var asserted = map[repository.InterfaceRef]bool{}
+	for _, edge := range repository.Edges(repository.Main) {
+		if edge.Kind == repository.AsserterEdge {
+			asserted[edge.To] = true
+		}
+	}
+
+	var ifaces []iface
+	var byRef = map[repository.InterfaceRef]iface{}
+
+	for _, pkgPath := range paths {
+		for _, i := range repository.Main[pkgPath].Interfaces {
+			ref := repository.InterfaceRef{Path: pkgPath, Name: i.Name}
+
+			iface := iface{
+				Path:      pkgPath,
+				Name:      i.Name,
+				Asserters: asserters(pkgPath, i),
+			}
+
+			if len(iface.Asserters) == 0 && !asserted[ref] {
+				continue
+			}
+
+			ifaces = append(ifaces, iface)
+			byRef[ref] = iface
+		}
+	}
+
+	// Check for cycles.
+	var visiting = map[repository.InterfaceRef]bool{}
+	var visit func(ref repository.InterfaceRef, stack []string)
+
+	visit = func(ref repository.InterfaceRef, stack []string) {
+		stack = append(stack, ref.Name)
+		if visiting[ref] {
+			log.Fatalln("Asserter cycle:", strings.Join(stack, " -> "))
+		}
+
+		visiting[ref] = true
+		for _, child := range byRef[ref].Asserters {
+			visit(child, stack)
+		}
+		visiting[ref] = false
+	}
+
+	for ref := range byRef {
+		visit(ref, nil)
+	}
+
+	return ifaces
+}
+
+// asserters returns the interfaces that the given interface can be asserted
+// into, including the ones from embedded interfaces.
+func asserters(pkgPath string, i repository.Interface) []repository.InterfaceRef {
+	var refs []repository.InterfaceRef
+
+	for _, embed := range i.Embeds {
+		embedPath, name := repository.TypeQual(embed.InterfaceName)
+		if embedPath == "" {
+			embedPath = pkgPath
+		}
+
+		if embedded := repository.Main[embedPath].Interface(name); embedded != nil {
+			refs = append(refs, asserters(embedPath, *embedded)...)
+		}
+	}
+
+	for _, method := range i.Methods {
+		asserter, ok := method.(repository.AsserterMethod)
+		if !ok {
+			continue
+		}
+
+		childPath, name := asserter.Qual()
+		if childPath == "" {
+			childPath = pkgPath
+		}
+
+		refs = append(refs, repository.InterfaceRef{Path: childPath, Name: name})
+	}
+
+	return refs
+}
+
+func genCapFunc(iface iface) jen.Code {
+	var name = funcName(iface.Path, iface.Name)
+
+	var stmt = new(jen.Statement)
+
+	if len(iface.Asserters) == 0 {
+		stmt.Commentf("%s returns the capability of v.", name)
+	} else {
+		stmt.Commentf("%s returns the capability of v and its asserted interfaces.", name)
+	}
+	stmt.Line()
+
+	stmt.Func().Id(name).Params(jen.Id("v").Qual(iface.Path, iface.Name)).Id("Capability")
+	stmt.BlockFunc(func(g *jen.Group) {
+		var capability = jen.Id("Capability").Values(jen.Dict{
+			jen.Id("Name"):        jen.Lit(typeName(iface.Path, iface.Name)),
+			jen.Id("Implemented"): jen.Id("v").Op("!=").Nil(),
+		})
+
+		if len(iface.Asserters) == 0 {
+			g.Return(capability)
+			return
+		}
+
+		g.Id("c").Op(":=").Add(capability)
+		g.If(jen.Op("!").Id("c").Dot("Implemented")).Block(jen.Return(jen.Id("c")))
+		g.Line()
+
+		g.Id("c").Dot("Asserted").Op("=").Index().Id("Capability").ValuesFunc(func(g *jen.Group) {
+			for _, child := range iface.Asserters {
+				g.Line().Id(funcName(child.Path, child.Name)).Call(
+					jen.Id("v").Dot("As" + child.Name).Call(),
+				)
+			}
+			g.Line()
+		})
+
+		g.Return(jen.Id("c"))
+	})
+
+	return stmt
+}
+
+// funcName returns the name of the generated function for the interface. It
+// is the same as the type name in package empty.
+func funcName(pkgPath, name string) string {
+	if pkgPath == repository.RootPath {
+		return name
+	}
+	return strings.Title(repository.TrimRoot(pkgPath)) + name
+}
+
+// typeName returns the Go type name of the interface, such as "text.Segment".
+func typeName(pkgPath, name string) string {
+	return fmt.Sprintf("%s.%s", path.Base(pkgPath), name)
+}
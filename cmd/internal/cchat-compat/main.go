@@ -0,0 +1,77 @@
+// Command cchat-compat compares two snapshots of the cchat repository and
+// prints the changes between them. It exits with a non-zero status code if any
+// of the changes are breaking.
+//
+// Usage
+//
+// The snapshots are gob files generated by cchat-gob-gen. If the new snapshot
+// is omitted, then the repository that the command is built with is used.
+// Snapshots generated before methods were gob encoded with their names are
+// decoded without their methods, in which case methods are not compared.
+//
+//    git show HEAD~1:repository/gob/repository.gob > /tmp/old.gob
+//    go run ./cmd/internal/cchat-compat /tmp/old.gob [new.gob]
+//
+package main
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+
+	"github.com/diamondburned/cchat/repository"
+)
+
+func main() {
+	log.SetFlags(0)
+
+	if len(os.Args) < 2 || len(os.Args) > 3 {
+		log.Fatalln("Usage:", os.Args[0], "old.gob [new.gob]")
+	}
+
+	old, oldLegacy := decode(os.Args[1])
+	new, newLegacy := repository.Main, false
+	if len(os.Args) == 3 {
+		new, newLegacy = decode(os.Args[2])
+	}
+
+	if oldLegacy || newLegacy {
+		log.Println("Legacy snapshot given, methods are not compared.")
+		old = repository.StripMethods(old)
+		new = repository.StripMethods(new)
+	}
+
+	changes := repository.Compare(old, new)
+	for _, change := range changes {
+		fmt.Println(change)
+	}
+
+	if repository.HasBreaking(changes) {
+		os.Exit(1)
+	}
+}
+
+// decode decodes the snapshot at path. It returns true if the snapshot could
+// only be decoded as a legacy snapshot.
+func decode(path string) (repository.Packages, bool) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		log.Fatalln("Failed to read file:", err)
+	}
+
+	var pkgs repository.Packages
+
+	if err := gob.NewDecoder(bytes.NewReader(b)).Decode(&pkgs); err == nil {
+		return pkgs, false
+	}
+
+	pkgs, err = repository.DecodeLegacyGob(bytes.NewReader(b))
+	if err != nil {
+		log.Fatalln("Failed to gob decode:", err)
+	}
+
+	return pkgs, true
+}
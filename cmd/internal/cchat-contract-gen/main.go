@@ -0,0 +1,170 @@
+package main
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dave/jennifer/jen"
+	"github.com/diamondburned/cchat/cmd/internal/cchat-generator/genutils"
+	"github.com/diamondburned/cchat/repository"
+)
+
+func init() {
+	log.SetFlags(0)
+}
+
+// rules maps container methods to the Checker methods that check their
+// arguments.
+var rules = map[string]string{
+	"cchat.ServersContainer.SetServers":     "setServers",
+	"cchat.ServersContainer.UpdateServer":   "updateServer",
+	"cchat.MessagesContainer.CreateMessage": "createMessage",
+	"cchat.MessagesContainer.UpdateMessage": "updateMessage",
+	"cchat.MessagesContainer.DeleteMessage": "deleteMessage",
+}
+
+var richType = repository.MakeQual("text", "Rich")
+
+// messengerType is asserted with the ID of its server, so that Backlog and
+// JoinServer are matched across all Messengers of the same server.
+const messengerType = "Messenger"
+
+func main() {
+	w := genutils.NewWrappers(genutils.NewFile("contract"), jen.Op("*").Id("Checker"))
+
+	for _, wrapped := range w.Wrapped {
+		w.GenerateType(wrapped,
+			wrapped.FuncName()+" wraps v to check the containers given to its methods with t.",
+			"Nil is returned if v is nil.",
+		)
+
+		var container = strings.HasSuffix(wrapped.Name, "Container")
+
+		for _, method := range wrapped.Methods {
+			sig, ok := genutils.NewSignature(method)
+			switch {
+			case !ok && isMessenger(method):
+				genMessenger(w, wrapped, method)
+			case !ok:
+				w.GenerateAsserter(wrapped, method)
+			case sig.IsBlocking():
+				genBlocking(w, wrapped, sig)
+			case container:
+				genContainer(w, wrapped, sig)
+			default:
+				genPassthrough(w, wrapped, sig)
+			}
+			w.Line()
+		}
+	}
+
+	w.GenerateSlices()
+
+	f, err := os.Create(filepath.Join(os.Args[1], "contract_gen.go"))
+	if err != nil {
+		log.Fatalln("Failed to create output file:", err)
+	}
+	defer f.Close()
+
+	if err := w.Render(f); err != nil {
+		log.Fatalln("Failed to render output:", err)
+	}
+}
+
+func genPassthrough(w *genutils.Wrappers, wrapped genutils.Wrapped, sig genutils.Signature) {
+	w.Add(sig.Func(wrapped.TypeName()).BlockFunc(func(g *jen.Group) {
+		var returns = sig.ReturnIdents()
+		if len(returns) == 0 {
+			g.Add(sig.Call(w))
+			return
+		}
+
+		g.List(returns...).Op(":=").Add(sig.Call(w))
+		g.Return(sig.WrappedReturns(w)...)
+	}))
+}
+
+func isMessenger(method genutils.Method) bool {
+	asserter, ok := method.Method.(repository.AsserterMethod)
+	return ok && method.Path == repository.RootPath && asserter.ChildType == messengerType
+}
+
+// genMessenger generates an asserter that gives the Messenger its own set of
+// message IDs.
+func genMessenger(w *genutils.Wrappers, wrapped genutils.Wrapped, method genutils.Method) {
+	var name = method.Method.UnderlyingName()
+
+	w.Func().Params(jen.Id("w").Id(wrapped.TypeName())).Id(name).Params().
+		Qual(repository.RootPath, messengerType).
+		Block(jen.Return(jen.Id(genutils.WrapperName(repository.RootPath, messengerType)).Call(
+			jen.Id("w").Dot("v").Dot(name).Call(),
+			jen.Id("w").Dot("t").Dot("messenger").Call(jen.Id("w").Dot("v").Dot("ID").Call()),
+		)))
+}
+
+// genBlocking generates a method that gives the containers in its arguments a
+// new scope, which is stopped with the stop callback for container methods or
+// once the method returns for other methods.
+func genBlocking(w *genutils.Wrappers, wrapped genutils.Wrapped, sig genutils.Signature) {
+	w.Add(sig.Func(wrapped.TypeName()).BlockFunc(func(g *jen.Group) {
+		g.Id("w").Dot("t").Op("=").Id("w").Dot("t").Dot("enter").Call(jen.Lit(sig.Method.GoName()))
+
+		if sig.IsContainer() {
+			g.List(jen.Id("stop"), jen.Err()).Op(":=").Add(sig.Call(w))
+			g.Return(jen.Id("w").Dot("t").Dot("stopFunc").Call(jen.Id("stop"), jen.Err()), jen.Err())
+			return
+		}
+
+		g.Defer().Id("w").Dot("t").Dot("stop").Call()
+		g.List(sig.ReturnIdents()...).Op(":=").Add(sig.Call(w))
+		g.Return(sig.WrappedReturns(w)...)
+	}))
+}
+
+// genContainer generates a container method that only calls the frontend if
+// the call follows all contracts.
+func genContainer(w *genutils.Wrappers, wrapped genutils.Wrapped, sig genutils.Signature) {
+	if len(sig.ReturnTypes()) > 0 {
+		log.Fatalln("Unsupported container method with return values:", sig.Method.GoName())
+	}
+
+	var method = jen.Id("method")
+	var checks = []jen.Code{
+		jen.Op("!").Id("w").Dot("t").Dot("call").Call(method),
+	}
+
+	if rule, ok := rules[sig.Method.GoName()]; ok {
+		var args = []jen.Code{method}
+		for _, param := range sig.Params {
+			args = append(args, jen.Id(param.Ident))
+		}
+		checks = append(checks, jen.Op("!").Id("w").Dot("t").Dot(rule).Call(args...))
+	}
+
+	for _, param := range sig.Params {
+		if param.Type == richType {
+			checks = append(checks,
+				jen.Op("!").Id("w").Dot("t").Dot("checkRich").Call(method, jen.Id(param.Ident)))
+		}
+	}
+
+	w.Add(sig.Func(wrapped.TypeName()).Block(
+		jen.Const().Id("method").Op("=").Lit(sig.Method.GoName()),
+		jen.If(jen.Add(join(checks)...)).Block(jen.Return()),
+		sig.Call(w),
+	))
+}
+
+// join joins the given conditions with ||.
+func join(conds []jen.Code) []jen.Code {
+	var joined []jen.Code
+	for i, cond := range conds {
+		if i > 0 {
+			joined = append(joined, jen.Op("||"))
+		}
+		joined = append(joined, cond)
+	}
+	return joined
+}
@@ -0,0 +1,61 @@
+// Command cchat-doc-gen renders a browsable API reference of the cchat
+// repository in either Markdown or HTML. Unlike godoc, the reference starts
+// with the capability tree, which shows how each interface is reached from
+// Service through asserters, containers and returned values.
+//
+// Usage
+//
+//    go run ./cmd/internal/cchat-doc-gen -format html -o reference.html
+//
+package main
+
+import (
+	"flag"
+	"io"
+	"log"
+	"os"
+)
+
+func init() {
+	log.SetFlags(0)
+}
+
+func main() {
+	var (
+		format = "md"
+		output = ""
+		root   = "Service"
+	)
+
+	flag.StringVar(&format, "format", format, "output format, either md or html")
+	flag.StringVar(&output, "o", output, "output file, default stdout")
+	flag.StringVar(&root, "root", root, "root interface of the capability tree")
+	flag.Parse()
+
+	var render func(io.Writer, Reference) error
+
+	switch format {
+	case "md":
+		render = renderMarkdown
+	case "html":
+		render = renderHTML
+	default:
+		log.Fatalln("Unknown format:", format)
+	}
+
+	var w io.Writer = os.Stdout
+
+	if output != "" {
+		f, err := os.Create(output)
+		if err != nil {
+			log.Fatalln("Failed to create file:", err)
+		}
+		defer f.Close()
+
+		w = f
+	}
+
+	if err := render(w, NewReference(root)); err != nil {
+		log.Fatalln("Failed to render:", err)
+	}
+}
@@ -0,0 +1,325 @@
+package main
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/diamondburned/cchat/repository"
+)
+
+// WrapColumn is the column that comments are wrapped at.
+const WrapColumn = 80
+
+// Reference is the data rendered into the API reference.
+type Reference struct {
+	Tree     []*TreeNode
+	Packages []Package
+}
+
+type Package struct {
+	Path    string
+	Name    string
+	Comment string
+
+	Enums      []Type
+	Aliases    []Type
+	Structs    []Type
+	Interfaces []Interface
+}
+
+// Type is a documented non-interface type.
+type Type struct {
+	Name    string
+	Anchor  string
+	Comment string
+	// Lines are either enum values, struct fields or the aliased type.
+	Lines []Line
+}
+
+type Line struct {
+	Code    string
+	Comment string
+}
+
+type Interface struct {
+	Name    string
+	Anchor  string
+	Comment string
+
+	Embeds     []Link
+	Methods    []Method
+	AssertedBy []Link
+}
+
+type Method struct {
+	Signature string
+	Comment   string
+	Badges    []string
+	// Link is non-empty if the method refers to another interface, such as an
+	// asserter.
+	Link Link
+}
+
+type Link struct {
+	Name   string
+	Anchor string
+}
+
+// IsZero returns true if the link is empty.
+func (l Link) IsZero() bool { return l.Anchor == "" }
+
+// NewReference creates the reference of repository.Main.
+func NewReference(root string) Reference {
+	var ref = Reference{
+		Tree: NewTree(repository.Main, repository.InterfaceRef{
+			Path: repository.RootPath,
+			Name: root,
+		}),
+	}
+
+	var assertedBy = map[repository.InterfaceRef][]Link{}
+	for _, edge := range repository.Edges(repository.Main) {
+		if edge.Kind == repository.AsserterEdge {
+			assertedBy[edge.To] = append(assertedBy[edge.To], refLink(edge.From))
+		}
+	}
+
+	// Root package first.
+	paths := []string{repository.RootPath}
+	for pkgPath := range repository.Main {
+		if pkgPath != repository.RootPath {
+			paths = append(paths, pkgPath)
+		}
+	}
+	sort.Strings(paths[1:])
+
+	for _, pkgPath := range paths {
+		ref.Packages = append(ref.Packages, newPackage(pkgPath, assertedBy))
+	}
+
+	return ref
+}
+
+func newPackage(pkgPath string, assertedBy map[repository.InterfaceRef][]Link) Package {
+	pkg := repository.Main[pkgPath]
+
+	var doc = Package{
+		Path:    pkgPath,
+		Name:    path.Base(pkgPath),
+		Comment: pkg.Comment.WrapText(WrapColumn),
+	}
+
+	for _, enum := range pkg.Enums {
+		typ := newType(pkgPath, enum.Name, enum.Comment)
+		for _, value := range enum.Values {
+			if value.IsPlaceholder() {
+				continue
+			}
+			typ.Lines = append(typ.Lines, Line{
+				Code:    enum.Name + value.Name,
+				Comment: value.Comment.WrapText(WrapColumn),
+			})
+		}
+		doc.Enums = append(doc.Enums, typ)
+	}
+
+	for _, alias := range pkg.TypeAliases {
+		typ := newType(pkgPath, alias.Name, alias.Comment)
+		typ.Lines = []Line{{Code: "= " + typeName(alias.Type)}}
+		doc.Aliases = append(doc.Aliases, typ)
+	}
+
+	addStruct := func(sstruct repository.Struct) {
+		typ := newType(pkgPath, sstruct.Name, sstruct.Comment)
+		for _, field := range sstruct.Fields {
+			typ.Lines = append(typ.Lines, Line{
+				Code:    field.Name + " " + typeName(field.Type),
+				Comment: field.Comment.WrapText(WrapColumn),
+			})
+		}
+		doc.Structs = append(doc.Structs, typ)
+	}
+
+	for _, sstruct := range pkg.Structs {
+		addStruct(sstruct)
+	}
+	for _, estruct := range pkg.ErrorStructs {
+		addStruct(estruct.Struct)
+	}
+
+	for _, iface := range pkg.Interfaces {
+		ref := repository.InterfaceRef{Path: pkgPath, Name: iface.Name}
+
+		idoc := Interface{
+			Name:       iface.Name,
+			Anchor:     anchor(pkgPath, iface.Name),
+			Comment:    iface.Comment.WrapText(WrapColumn),
+			AssertedBy: assertedBy[ref],
+		}
+
+		for _, embed := range iface.Embeds {
+			idoc.Embeds = append(idoc.Embeds, typeLink(pkgPath, embed.InterfaceName))
+		}
+
+		for _, method := range iface.Methods {
+			idoc.Methods = append(idoc.Methods, newMethod(pkgPath, method))
+		}
+
+		doc.Interfaces = append(doc.Interfaces, idoc)
+	}
+
+	return doc
+}
+
+func newType(pkgPath, name string, comment repository.Comment) Type {
+	return Type{
+		Name:    name,
+		Anchor:  anchor(pkgPath, name),
+		Comment: comment.WrapText(WrapColumn),
+	}
+}
+
+func newMethod(pkgPath string, method repository.Method) Method {
+	var doc = Method{
+		Comment: method.UnderlyingComment().WrapText(WrapColumn),
+	}
+
+	var name = method.UnderlyingName()
+
+	switch method := method.(type) {
+	case repository.GetterMethod:
+		doc.Badges = []string{"Getter"}
+		doc.Signature = name + params(method.Parameters) +
+			returns(method.Returns, method.ErrorType)
+
+	case repository.SetterMethod:
+		doc.Badges = []string{"Setter"}
+		doc.Signature = name + params(method.Parameters) +
+			returns(nil, method.ErrorType)
+
+	case repository.ContainerUpdaterMethod:
+		doc.Badges = []string{"Updater"}
+		doc.Signature = name + params(withContext(method.Parameters)) +
+			returns(nil, method.ErrorType)
+
+	case repository.IOMethod:
+		doc.Badges = []string{"Blocking"}
+		if method.Disposer {
+			doc.Badges = append(doc.Badges, "Disposer")
+		}
+
+		var rets []repository.NamedType
+		if !method.ReturnValue.IsZero() {
+			rets = []repository.NamedType{method.ReturnValue}
+		}
+
+		doc.Signature = name + params(withContext(method.Parameters)) +
+			returns(rets, method.ErrorType)
+
+	case repository.ContainerMethod:
+		doc.Badges = []string{"Container"}
+		doc.Link = typeLink(pkgPath, method.ContainerType)
+
+		var ps = []repository.NamedType{{Type: method.ContainerType}}
+		if method.HasContext {
+			ps = withContext(ps)
+		}
+
+		doc.Signature = name + params(ps) + " (stop func(), err error)"
+
+	case repository.AsserterMethod:
+		doc.Badges = []string{"Optional"}
+		doc.Link = typeLink(pkgPath, method.ChildType)
+		doc.Signature = name + "() " + typeName(method.ChildType)
+	}
+
+	return doc
+}
+
+func withContext(params []repository.NamedType) []repository.NamedType {
+	var name string
+	if len(params) > 0 && params[0].Name != "" {
+		name = "ctx"
+	}
+
+	ctx := repository.NamedType{Name: name, Type: "context.Context"}
+	return append([]repository.NamedType{ctx}, params...)
+}
+
+func params(types []repository.NamedType) string {
+	var strs = make([]string, len(types))
+	for i, typ := range types {
+		strs[i] = namedType(typ)
+	}
+	return "(" + strings.Join(strs, ", ") + ")"
+}
+
+func returns(types []repository.NamedType, errorType string) string {
+	if errorType != "" {
+		var name string
+		if len(types) > 0 && types[0].Name != "" {
+			name = "err"
+		}
+		types = append(types[:len(types):len(types)], repository.NamedType{
+			Name: name,
+			Type: errorType,
+		})
+	}
+
+	switch {
+	case len(types) == 0:
+		return ""
+	case len(types) == 1 && types[0].Name == "":
+		return " " + typeName(types[0].Type)
+	default:
+		return " " + params(types)
+	}
+}
+
+func namedType(typ repository.NamedType) string {
+	if typ.Name == "" {
+		return typeName(typ.Type)
+	}
+	return typ.Name + " " + typeName(typ.Type)
+}
+
+// typeName returns the type name with its package path shortened to the last
+// element.
+func typeName(typ string) string {
+	var prefix string
+	if i := strings.IndexFunc(typ, func(r rune) bool {
+		return r != '[' && r != ']' && r != '*'
+	}); i > 0 {
+		prefix, typ = typ[:i], typ[i:]
+	}
+
+	typePath, name := repository.TypeQual(typ)
+	if typePath == "" {
+		return prefix + name
+	}
+
+	return prefix + path.Base(typePath) + "." + name
+}
+
+// typeLink returns a link to the given type name relative to the package path.
+func typeLink(pkgPath, typ string) Link {
+	typePath, name := repository.TypeQual(typ)
+	if typePath == "" {
+		typePath = pkgPath
+	}
+
+	return Link{
+		Name:   typeName(typ),
+		Anchor: anchor(typePath, name),
+	}
+}
+
+func refLink(ref repository.InterfaceRef) Link {
+	return typeLink(ref.Path, ref.Name)
+}
+
+// anchor returns the HTML anchor name of the type, such as "text-Rich".
+func anchor(pkgPath, name string) string {
+	return path.Base(pkgPath) + "-" + name
+}
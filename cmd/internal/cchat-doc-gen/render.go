@@ -0,0 +1,315 @@
+package main
+
+import (
+	"bytes"
+	"html/template"
+	"io"
+	"regexp"
+	"strings"
+	ttemplate "text/template"
+)
+
+// TreeLine is a flattened TreeNode.
+type TreeLine struct {
+	*TreeNode
+	Depth int
+}
+
+// Indent returns the Markdown list indentation of the line.
+func (l TreeLine) Indent() string {
+	return strings.Repeat("  ", l.Depth)
+}
+
+// TreeLines flattens the tree in depth-first order.
+func (r Reference) TreeLines() []TreeLine {
+	var lines []TreeLine
+	var walk func(nodes []*TreeNode, depth int)
+
+	walk = func(nodes []*TreeNode, depth int) {
+		for _, node := range nodes {
+			lines = append(lines, TreeLine{node, depth})
+			walk(node.Children, depth+1)
+		}
+	}
+
+	walk(r.Tree, 0)
+	return lines
+}
+
+// Paragraph is a paragraph of wrapped comment text.
+type Paragraph struct {
+	Text    string
+	Code    bool
+	Heading bool
+}
+
+// paragraphs splits text returned by WrapText into paragraphs. Indented
+// paragraphs are code blocks.
+func paragraphs(text string) []Paragraph {
+	var pars []Paragraph
+
+	for _, par := range strings.Split(text, "\n\n") {
+		if strings.TrimSpace(par) == "" {
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(par, " "):
+			pars = append(pars, Paragraph{Text: unindent(par), Code: true})
+		case strings.HasPrefix(par, "# "):
+			pars = append(pars, Paragraph{Text: strings.TrimPrefix(par, "# "), Heading: true})
+		default:
+			pars = append(pars, Paragraph{Text: par})
+		}
+	}
+
+	return pars
+}
+
+func unindent(text string) string {
+	lines := strings.Split(text, "\n")
+
+	var indent = -1
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if n := len(line) - len(strings.TrimLeft(line, " ")); indent == -1 || n < indent {
+			indent = n
+		}
+	}
+
+	for i, line := range lines {
+		if len(line) >= indent {
+			lines[i] = line[indent:]
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// markdown converts text returned by WrapText into Markdown. Code blocks are
+// fenced, and headings are demoted to not clash with the reference's own.
+func markdown(text string) string {
+	var pars = paragraphs(text)
+	var strs = make([]string, len(pars))
+
+	for i, par := range pars {
+		switch {
+		case par.Code:
+			strs[i] = "```\n" + par.Text + "\n```"
+		case par.Heading:
+			strs[i] = "#### " + par.Text
+		default:
+			strs[i] = par.Text
+		}
+	}
+
+	return strings.Join(strs, "\n\n")
+}
+
+// indent indents all non-empty lines with n spaces.
+func indent(n int, text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = strings.Repeat(" ", n) + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+var funcs = map[string]interface{}{
+	"indent":     indent,
+	"markdown":   markdown,
+	"paragraphs": paragraphs,
+	"join":       strings.Join,
+}
+
+var markdownTmpl = ttemplate.Must(ttemplate.New("md").Funcs(funcs).Parse(`
+{{- define "link" }}[{{ .Name }}](#{{ .Anchor }}){{ end -}}
+
+{{- define "links" }}{{ range $i, $l := . }}{{ if $i }}, {{ end }}{{ template "link" $l }}{{ end }}{{ end -}}
+
+{{- define "heading" -}}
+<a name="{{ .Anchor }}"></a>
+
+### {{ .Name }}
+{{ with .Comment }}
+{{ markdown . }}
+{{ end -}}
+{{ end -}}
+
+{{- define "item" }}
+- ` + "`{{ .Code }}`" + `
+{{- with .Comment }}
+
+{{ indent 2 (markdown .) }}
+{{ end -}}
+{{ end -}}
+
+# cchat API reference
+
+## Capability tree
+
+{{ range .TreeLines -}}
+{{ .Indent }}- {{ with .Method }}` + "`{{ . }}`" + ` → {{ end }}{{ template "link" .Link }}
+{{- if .Kind }} _({{ .Kind }})_{{ end }}{{ if .Repeated }} ↑{{ end }}
+{{ end -}}
+
+{{ range .Packages }}
+## Package {{ .Name }}
+
+` + "`{{ .Path }}`" + `
+{{ with .Comment }}
+{{ markdown . }}
+{{ end -}}
+
+{{ range .Interfaces }}
+
+{{ template "heading" . -}}
+{{ with .AssertedBy }}
+Asserted by {{ template "links" . }}.
+{{ end -}}
+{{ with .Embeds }}
+Embeds {{ template "links" . }}.
+{{ end -}}
+{{ range .Methods }}
+- ` + "`{{ .Signature }}`" + `{{ range .Badges }} **{{ . }}**{{ end }}
+{{- if not .Link.IsZero }} → {{ template "link" .Link }}{{ end }}
+{{- with .Comment }}
+
+{{ indent 2 (markdown .) }}
+{{ end -}}
+{{ end -}}
+{{ end -}}
+
+{{ range .Enums }}
+
+{{ template "heading" . -}}
+{{ range .Lines }}{{ template "item" . }}{{ end -}}
+{{ end -}}
+
+{{ range .Aliases }}
+
+{{ template "heading" . -}}
+{{ range .Lines }}{{ template "item" . }}{{ end -}}
+{{ end -}}
+
+{{ range .Structs }}
+
+{{ template "heading" . -}}
+{{ range .Lines }}{{ template "item" . }}{{ end -}}
+{{ end -}}
+{{ end -}}
+`))
+
+// blankLines matches consecutive blank lines.
+var blankLines = regexp.MustCompile(`\n{3,}`)
+
+func renderMarkdown(w io.Writer, ref Reference) error {
+	var buf bytes.Buffer
+	if err := markdownTmpl.Execute(&buf, ref); err != nil {
+		return err
+	}
+
+	// The template is a lot more readable without trimming every blank line.
+	b := blankLines.ReplaceAll(buf.Bytes(), []byte("\n\n"))
+
+	_, err := w.Write(b)
+	return err
+}
+
+var htmlTmpl = template.Must(template.New("html").Funcs(funcs).Parse(`
+{{- define "link" }}<a href="#{{ .Anchor }}">{{ .Name }}</a>{{ end -}}
+
+{{- define "comment" -}}
+{{ range paragraphs . -}}
+{{ if .Code }}<pre>{{ .Text }}</pre>{{ else if .Heading }}<h4>{{ .Text }}</h4>{{ else }}<p>{{ .Text }}</p>{{ end }}
+{{ end -}}
+{{ end -}}
+
+{{- define "tree" -}}
+<ul>
+{{ range . -}}
+<li>
+{{- with .Method }}<code>{{ . }}</code> → {{ end }}{{ template "link" .Link }}
+{{- if .Kind }} <span class="kind">{{ .Kind }}</span>{{ end }}
+{{- if .Repeated }} <span class="repeated">↑</span>{{ end }}
+{{- with .Children }}
+{{ template "tree" . }}{{ end -}}
+</li>
+{{ end -}}
+</ul>
+{{ end -}}
+
+{{- define "type" -}}
+<section id="{{ .Anchor }}">
+<h3>{{ .Name }}</h3>
+{{ template "comment" .Comment -}}
+{{ with .Lines -}}
+<dl>
+{{ range . -}}
+<dt><code>{{ .Code }}</code></dt>
+<dd>{{ template "comment" .Comment }}</dd>
+{{ end -}}
+</dl>
+{{ end -}}
+</section>
+{{ end -}}
+
+<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>cchat API reference</title>
+<style>
+body { max-width: 60em; margin: auto; font-family: sans-serif; }
+pre { background: #f4f4f4; padding: 0.5em; }
+.badge { font-size: 0.8em; padding: 0 0.4em; border-radius: 0.4em; background: #ddd; }
+.badge.Blocking { background: #fdd; }
+.badge.Disposer { background: #fbb; }
+.badge.Container { background: #dfd; }
+.badge.Optional { background: #ddf; }
+.kind, .repeated { color: #888; font-size: 0.8em; }
+</style>
+</head>
+<body>
+<h1>cchat API reference</h1>
+<h2>Capability tree</h2>
+{{ template "tree" .Tree }}
+{{- range .Packages }}
+<h2>Package {{ .Name }}</h2>
+<p><code>{{ .Path }}</code></p>
+{{ template "comment" .Comment -}}
+{{ range .Interfaces -}}
+<section id="{{ .Anchor }}">
+<h3>{{ .Name }}</h3>
+{{ template "comment" .Comment -}}
+{{ with .AssertedBy -}}
+<p>Asserted by {{ range $i, $l := . }}{{ if $i }}, {{ end }}{{ template "link" $l }}{{ end }}.</p>
+{{ end -}}
+{{ with .Embeds -}}
+<p>Embeds {{ range $i, $l := . }}{{ if $i }}, {{ end }}{{ template "link" $l }}{{ end }}.</p>
+{{ end -}}
+<dl>
+{{ range .Methods -}}
+<dt><code>{{ .Signature }}</code>
+{{- range .Badges }} <span class="badge {{ . }}">{{ . }}</span>{{ end }}
+{{- if not .Link.IsZero }} → {{ template "link" .Link }}{{ end }}</dt>
+<dd>{{ template "comment" .Comment }}</dd>
+{{ end -}}
+</dl>
+</section>
+{{ end -}}
+{{ range .Enums }}{{ template "type" . }}{{ end -}}
+{{ range .Aliases }}{{ template "type" . }}{{ end -}}
+{{ range .Structs }}{{ template "type" . }}{{ end -}}
+{{ end -}}
+</body>
+</html>
+`))
+
+func renderHTML(w io.Writer, ref Reference) error {
+	return htmlTmpl.Execute(w, ref)
+}
@@ -0,0 +1,90 @@
+package main
+
+import "github.com/diamondburned/cchat/repository"
+
+// TreeNode is a node in the capability tree.
+type TreeNode struct {
+	Link Link
+	// Kind is the kind of edge from the parent, such as "asserter".
+	Kind string
+	// Method is the parent's method that leads to this node, if any.
+	Method string
+	// Repeated is true if the node is already expanded elsewhere in the tree,
+	// in which case it has no children.
+	Repeated bool
+	Children []*TreeNode
+}
+
+// NewTree creates a capability tree from the given root interface. Each
+// interface is expanded once at its shallowest depth; later occurrences are
+// marked as repeated. Embedded interfaces and containers don't add to the
+// depth, so Server is expanded under Session's ServersContainer rather than
+// under one of Session's asserters. Embedded interfaces that lead nowhere, such
+// as Identifier, are omitted.
+func NewTree(pkgs repository.Packages, root repository.InterfaceRef) []*TreeNode {
+	var edges = map[repository.InterfaceRef][]repository.Edge{}
+	for _, edge := range repository.Edges(pkgs) {
+		edges[edge.From] = append(edges[edge.From], edge)
+	}
+
+	var rootNode = &TreeNode{Link: refLink(root)}
+	var visited = map[repository.InterfaceRef]bool{root: true}
+
+	type queued struct {
+		ref  repository.InterfaceRef
+		node *TreeNode
+	}
+
+	var queue = []queued{{root, rootNode}}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		var seen = map[repository.InterfaceRef]bool{}
+		var front []queued
+
+		for _, edge := range edges[current.ref] {
+			if seen[edge.To] || edge.To == current.ref {
+				continue
+			}
+			seen[edge.To] = true
+
+			if edge.Kind == repository.EmbedEdge && len(edges[edge.To]) == 0 {
+				continue
+			}
+
+			// Values such as text segments are returned by many methods, so
+			// only the first one is shown.
+			if edge.Kind == repository.ValueEdge && visited[edge.To] {
+				continue
+			}
+
+			child := &TreeNode{
+				Link:     refLink(edge.To),
+				Kind:     edge.Kind.String(),
+				Method:   edge.Method,
+				Repeated: visited[edge.To],
+			}
+
+			current.node.Children = append(current.node.Children, child)
+
+			if child.Repeated {
+				continue
+			}
+
+			visited[edge.To] = true
+
+			switch edge.Kind {
+			case repository.EmbedEdge, repository.ContainerEdge:
+				front = append(front, queued{edge.To, child})
+			default:
+				queue = append(queue, queued{edge.To, child})
+			}
+		}
+
+		queue = append(front, queue...)
+	}
+
+	return []*TreeNode{rootNode}
+}
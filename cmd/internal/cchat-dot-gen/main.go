@@ -0,0 +1,203 @@
+// Command cchat-dot-gen writes the capability graph of the cchat repository
+// in the Graphviz DOT format. Interfaces are grouped by package, and edges are
+// drawn for embedded interfaces (dashed), asserters (solid) and containers
+// (bold). Edges for returned values can be drawn as well (dotted).
+//
+// Usage
+//
+//    go run ./cmd/internal/cchat-dot-gen -unreachable | dot -Tsvg > cchat.svg
+//
+// With -unreachable, interfaces that cannot be reached from the root interface
+// are filled in red. By default, only embedded interfaces and asserters are
+// followed, as well as the edges of the methods that Sessions and Servers are
+// obtained through, such as Authenticator.Authenticate. The edge kinds followed
+// can be changed with -via, such as "-via embed,asserter,container", and the
+// methods whose edges are always followed with -through.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/diamondburned/cchat/repository"
+)
+
+func init() {
+	log.SetFlags(0)
+}
+
+var edgeStyles = map[repository.EdgeKind]string{
+	repository.EmbedEdge:     `style=dashed`,
+	repository.AsserterEdge:  `style=solid`,
+	repository.ContainerEdge: `style=bold, color="#2e7d32"`,
+	repository.ValueEdge:     `style=dotted, color="#666666"`,
+}
+
+func main() {
+	var (
+		output      = ""
+		root        = "Service"
+		values      = false
+		unreachable = false
+		via         = "embed,asserter"
+		through     = "Service.Authenticate,Authenticator.Authenticate,Lister.Servers,ServersContainer.SetServers"
+	)
+
+	flag.StringVar(&output, "o", output, "output file, default stdout")
+	flag.StringVar(&root, "root", root, "root interface for -unreachable")
+	flag.BoolVar(&values, "values", values, "also draw edges for returned values")
+	flag.BoolVar(&unreachable, "unreachable", unreachable, "highlight unreachable interfaces")
+	flag.StringVar(&via, "via", via, "comma-separated edge kinds followed by -unreachable")
+	flag.StringVar(&through, "through", through,
+		"comma-separated root package methods, such as Interface.Method, "+
+			"whose edges are also followed by -unreachable")
+	flag.Parse()
+
+	var w io.Writer = os.Stdout
+
+	if output != "" {
+		f, err := os.Create(output)
+		if err != nil {
+			log.Fatalln("Failed to create file:", err)
+		}
+		defer f.Close()
+
+		w = f
+	}
+
+	var g = graph{
+		pkgs:   repository.Main,
+		values: values,
+	}
+
+	if unreachable {
+		kinds, err := parseKinds(via)
+		if err != nil {
+			log.Fatalln("Invalid -via:", err)
+		}
+
+		var follow = map[repository.EdgeKind]bool{}
+		for _, kind := range kinds {
+			follow[kind] = true
+		}
+
+		var methods = map[string]bool{}
+		if through != "" {
+			for _, method := range strings.Split(through, ",") {
+				methods[method] = true
+			}
+		}
+
+		rootRef := repository.InterfaceRef{Path: repository.RootPath, Name: root}
+		g.reachable = repository.ReachableFunc(g.pkgs, rootRef, func(edge repository.Edge) bool {
+			if follow[edge.Kind] {
+				return true
+			}
+			return edge.From.Path == repository.RootPath &&
+				methods[edge.From.Name+"."+edge.Method]
+		})
+	}
+
+	if err := g.write(w); err != nil {
+		log.Fatalln("Failed to write graph:", err)
+	}
+}
+
+func parseKinds(str string) ([]repository.EdgeKind, error) {
+	var kinds []repository.EdgeKind
+
+parse:
+	for _, name := range strings.Split(str, ",") {
+		for kind := range edgeStyles {
+			if kind.String() == name {
+				kinds = append(kinds, kind)
+				continue parse
+			}
+		}
+		return nil, fmt.Errorf("unknown edge kind %q", name)
+	}
+
+	return kinds, nil
+}
+
+type graph struct {
+	pkgs   repository.Packages
+	values bool
+	// reachable is nil if unreachable interfaces should not be highlighted.
+	reachable map[repository.InterfaceRef]bool
+}
+
+func (g graph) write(w io.Writer) error {
+	buf := bufio.NewWriter(w)
+
+	fmt.Fprintln(buf, "digraph cchat {")
+	fmt.Fprintln(buf, "\trankdir=LR;")
+	fmt.Fprintln(buf, "\tnode [shape=box, fontname=\"sans-serif\"];")
+	fmt.Fprintln(buf, "\tedge [fontname=\"sans-serif\", fontsize=10];")
+
+	for i, pkgPath := range sortedPaths(g.pkgs) {
+		fmt.Fprintf(buf, "\n\tsubgraph cluster_%d {\n", i)
+		fmt.Fprintf(buf, "\t\tlabel=%q;\n", path.Base(pkgPath))
+
+		for _, iface := range g.pkgs[pkgPath].Interfaces {
+			ref := repository.InterfaceRef{Path: pkgPath, Name: iface.Name}
+
+			var attrs = []string{fmt.Sprintf("label=%q", iface.Name)}
+			if iface.IsContainer() {
+				attrs = append(attrs, "shape=ellipse")
+			}
+			if g.reachable != nil && !g.reachable[ref] {
+				attrs = append(attrs, `style=filled, fillcolor="#ef9a9a"`)
+			}
+
+			fmt.Fprintf(buf, "\t\t%s [%s];\n", nodeID(ref), strings.Join(attrs, ", "))
+		}
+
+		fmt.Fprintln(buf, "\t}")
+	}
+
+	fmt.Fprintln(buf)
+
+	for _, edge := range repository.Edges(g.pkgs) {
+		if edge.Kind == repository.ValueEdge && !g.values {
+			continue
+		}
+
+		var attrs = []string{edgeStyles[edge.Kind]}
+		if edge.Method != "" && edge.Kind != repository.AsserterEdge {
+			attrs = append(attrs, fmt.Sprintf("label=%q", edge.Method))
+		}
+
+		fmt.Fprintf(buf, "\t%s -> %s [%s];\n",
+			nodeID(edge.From), nodeID(edge.To), strings.Join(attrs, ", "))
+	}
+
+	fmt.Fprintln(buf, "}")
+
+	return buf.Flush()
+}
+
+// nodeID returns the quoted DOT node ID of the interface, such as
+// "text.Rich".
+func nodeID(ref repository.InterfaceRef) string {
+	return fmt.Sprintf("%q", path.Base(ref.Path)+"."+ref.Name)
+}
+
+func sortedPaths(pkgs repository.Packages) []string {
+	// Root package first.
+	paths := []string{repository.RootPath}
+	for pkgPath := range pkgs {
+		if pkgPath != repository.RootPath {
+			paths = append(paths, pkgPath)
+		}
+	}
+	sort.Strings(paths[1:])
+	return paths
+}
@@ -0,0 +1,100 @@
+package genutils
+
+import (
+	"path"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/dave/jennifer/jen"
+	"github.com/diamondburned/cchat/repository"
+)
+
+// Method is a method along with the interface that declares it.
+type Method struct {
+	repository.Method
+	Path      string
+	Interface string
+}
+
+// GoName returns the Go name of the method qualified with its declaring
+// interface, such as "cchat.Sender.Send".
+func (m Method) GoName() string {
+	return path.Base(m.Path) + "." + m.Interface + "." + m.UnderlyingName()
+}
+
+// InterfaceMethods returns all methods of the interface, including the ones
+// from embedded interfaces, which come first. Methods with the same name are
+// only returned once. False is returned if an embedded interface is not in
+// pkgs, such as error.
+func InterfaceMethods(pkgs repository.Packages, path string, iface repository.Interface) ([]Method, bool) {
+	var methods []Method
+	var seen = map[string]bool{}
+
+	var collect func(path string, iface repository.Interface) bool
+	collect = func(path string, iface repository.Interface) bool {
+		for _, embed := range iface.Embeds {
+			embedPath, name := repository.TypeQual(embed.InterfaceName)
+			if embedPath == "" {
+				embedPath = path
+			}
+
+			embedded := pkgs[embedPath].Interface(name)
+			if embedded == nil || !collect(embedPath, *embedded) {
+				return false
+			}
+		}
+
+		for _, method := range iface.Methods {
+			if name := method.UnderlyingName(); !seen[name] {
+				seen[name] = true
+				methods = append(methods, Method{method, path, iface.Name})
+			}
+		}
+
+		return true
+	}
+
+	if !collect(path, iface) {
+		return nil, false
+	}
+
+	return methods, true
+}
+
+// GenerateTypeName generates the type with the given name. Exported types
+// without a package are qualified with root. Slice and pointer prefixes are
+// kept.
+func GenerateTypeName(root, typ string) jen.Code {
+	var prefix = new(jen.Statement)
+
+	for {
+		switch {
+		case strings.HasPrefix(typ, "[]"):
+			prefix.Index()
+			typ = typ[2:]
+			continue
+		case strings.HasPrefix(typ, "*"):
+			prefix.Op("*")
+			typ = typ[1:]
+			continue
+		}
+		break
+	}
+
+	path, name := repository.TypeQual(typ)
+	switch {
+	case path != "":
+		return prefix.Qual(path, name)
+	case IsExported(name):
+		return prefix.Qual(root, name)
+	default:
+		return prefix.Id(name)
+	}
+}
+
+// IsExported returns true if the name starts with an upper-case letter.
+func IsExported(name string) bool {
+	r, _ := utf8.DecodeRuneInString(name)
+	return unicode.IsUpper(r)
+}
@@ -0,0 +1,360 @@
+package genutils
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/dave/jennifer/jen"
+	"github.com/diamondburned/cchat/repository"
+)
+
+// Wrapped is an interface that a wrapper is generated for.
+type Wrapped struct {
+	Path    string
+	Name    string
+	Methods []Method
+}
+
+// GoName returns the Go type name of the interface, such as "text.Segment".
+func (w Wrapped) GoName() string {
+	return fmt.Sprintf("%s.%s", path.Base(w.Path), w.Name)
+}
+
+// FuncName returns the name of the exported function that wraps the
+// interface. It is the same as the type name in package empty.
+func (w Wrapped) FuncName() string {
+	return WrapperName(w.Path, w.Name)
+}
+
+// TypeName returns the name of the unexported wrapper type.
+func (w Wrapped) TypeName() string {
+	return unexport(w.FuncName())
+}
+
+// WrapperName returns the name of the exported function that wraps the given
+// interface.
+func WrapperName(pkgPath, name string) string {
+	if pkgPath == repository.RootPath {
+		return name
+	}
+	return strings.Title(repository.TrimRoot(pkgPath)) + name
+}
+
+func unexport(name string) string {
+	return string(unicode.ToLower(rune(name[0]))) + name[1:]
+}
+
+// Wrappers helps generating wrappers for all interfaces in repository.Main.
+// Each wrapper type is a struct with the wrapped value in field v and the
+// state in field t. Values of interface types that cross a wrapper are wrapped
+// as well using the same state.
+type Wrappers struct {
+	*jen.File
+	State    jen.Code
+	Wrapped  []Wrapped
+	wrappers map[repository.InterfaceRef]bool
+	slices   map[repository.InterfaceRef]bool
+}
+
+// NewWrappers creates a new Wrappers for all interfaces whose methods are all
+// known, in stable order with the root package first.
+func NewWrappers(file *jen.File, state jen.Code) *Wrappers {
+	w := Wrappers{
+		File:     file,
+		State:    state,
+		wrappers: map[repository.InterfaceRef]bool{},
+		slices:   map[repository.InterfaceRef]bool{},
+	}
+
+	for _, pkgPath := range SortedPaths() {
+		file.ImportName(pkgPath, path.Base(pkgPath))
+
+		for _, iface := range repository.Main[pkgPath].Interfaces {
+			methods, ok := InterfaceMethods(repository.Main, pkgPath, iface)
+			if !ok {
+				continue
+			}
+
+			w.Wrapped = append(w.Wrapped, Wrapped{
+				Path:    pkgPath,
+				Name:    iface.Name,
+				Methods: methods,
+			})
+			w.wrappers[repository.InterfaceRef{Path: pkgPath, Name: iface.Name}] = true
+		}
+	}
+
+	return &w
+}
+
+// SortedPaths returns the paths of repository.Main with the root package
+// first.
+func SortedPaths() []string {
+	var paths = []string{repository.RootPath}
+	for pkgPath := range repository.Main {
+		if pkgPath != repository.RootPath {
+			paths = append(paths, pkgPath)
+		}
+	}
+	sort.Strings(paths[1:])
+	return paths
+}
+
+// GenerateType generates the wrapper type and its constructor, which returns
+// nil if the given value is nil. Each comment line is written above the
+// constructor.
+func (w *Wrappers) GenerateType(wrapped Wrapped, comment ...string) {
+	w.Type().Id(wrapped.TypeName()).Struct(
+		jen.Id("v").Qual(wrapped.Path, wrapped.Name),
+		jen.Id("t").Add(w.State),
+	)
+	w.Line()
+
+	for _, line := range comment {
+		w.Comment(line)
+	}
+	w.Func().Id(wrapped.FuncName()).
+		Params(jen.Id("v").Qual(wrapped.Path, wrapped.Name), jen.Id("t").Add(w.State)).
+		Qual(wrapped.Path, wrapped.Name).
+		Block(
+			jen.If(jen.Id("v").Op("==").Nil()).Block(jen.Return(jen.Nil())),
+			jen.Return(jen.Id(wrapped.TypeName()).Values(jen.Id("v"), jen.Id("t"))),
+		)
+	w.Line()
+}
+
+// GenerateAsserter generates an asserter method that wraps the returned
+// interface.
+func (w *Wrappers) GenerateAsserter(wrapped Wrapped, method Method) {
+	var m = method.Method.(repository.AsserterMethod)
+	var name = m.UnderlyingName()
+	var call = jen.Id("w").Dot("v").Dot(name).Call()
+
+	w.Func().Params(jen.Id("w").Id(wrapped.TypeName())).Id(name).Params().
+		Add(GenerateTypeName(method.Path, m.ChildType)).
+		Block(jen.Return(w.Wrap(method.Path, call, m.ChildType)))
+}
+
+// GenerateSlices generates the functions that wrap slices of interfaces. It
+// must be called after all wrappers are generated.
+func (w *Wrappers) GenerateSlices() {
+	for _, wrapped := range w.Wrapped {
+		if !w.slices[repository.InterfaceRef{Path: wrapped.Path, Name: wrapped.Name}] {
+			continue
+		}
+
+		typ := jen.Qual(wrapped.Path, wrapped.Name)
+
+		w.Func().Id(wrapped.TypeName()+"Slice").
+			Params(jen.Id("v").Index().Add(typ), jen.Id("t").Add(w.State)).
+			Index().Add(typ).
+			Block(
+				jen.If(jen.Id("v").Op("==").Nil()).Block(jen.Return(jen.Nil())),
+				jen.Id("w").Op(":=").Make(jen.Index().Add(typ), jen.Len(jen.Id("v"))),
+				jen.For(jen.Id("i").Op(":=").Range().Id("v")).Block(
+					jen.Id("w").Index(jen.Id("i")).Op("=").Id(wrapped.FuncName()).Call(
+						jen.Id("v").Index(jen.Id("i")), jen.Id("t"),
+					),
+				),
+				jen.Return(jen.Id("w")),
+			)
+		w.Line()
+	}
+}
+
+// Wrap returns the code that wraps v if its type is an interface with a
+// wrapper, or v as-is otherwise. The code uses w.t as the state.
+func (w *Wrappers) Wrap(pkgPath string, v jen.Code, typ string) jen.Code {
+	var slice bool
+	if strings.HasPrefix(typ, "[]") {
+		slice = true
+		typ = typ[2:]
+	}
+
+	typePath, name := repository.TypeQual(typ)
+	if typePath == "" {
+		typePath = pkgPath
+	}
+
+	ref := repository.InterfaceRef{Path: typePath, Name: name}
+	if !w.wrappers[ref] {
+		return v
+	}
+
+	var funcName = WrapperName(typePath, name)
+	if slice {
+		w.slices[ref] = true
+		funcName = unexport(funcName) + "Slice"
+	}
+
+	return jen.Id(funcName).Call(v, jen.Id("w").Dot("t"))
+}
+
+// Value is a parameter or a return value of a Signature.
+type Value struct {
+	Ident string
+	Type  string
+}
+
+// Signature is the Go signature of a method that isn't an asserter. Container
+// methods have a single parameter, which is the container, and a stop
+// callback as their only return value.
+type Signature struct {
+	Method
+	Name      string
+	Context   bool
+	Params    []Value // named a0, a1, ...
+	Returns   []Value // named r0, r1, ...
+	ErrorType string  // named err
+}
+
+// NewSignature creates the signature of the given method. False is returned
+// for asserters.
+func NewSignature(method Method) (Signature, bool) {
+	var sig = Signature{
+		Method: method,
+		Name:   method.UnderlyingName(),
+	}
+
+	switch m := method.Method.(type) {
+	case repository.GetterMethod:
+		sig.Params = values("a", m.Parameters)
+		sig.Returns = values("r", m.Returns)
+		sig.ErrorType = m.ErrorType
+	case repository.SetterMethod:
+		sig.Params = values("a", m.Parameters)
+		sig.ErrorType = m.ErrorType
+	case repository.ContainerUpdaterMethod:
+		sig.Context = true
+		sig.Params = values("a", m.Parameters)
+		sig.ErrorType = m.ErrorType
+	case repository.IOMethod:
+		sig.Context = true
+		sig.Params = values("a", m.Parameters)
+		if !m.ReturnValue.IsZero() {
+			sig.Returns = values("r", []repository.NamedType{m.ReturnValue})
+		}
+		sig.ErrorType = m.ErrorType
+	case repository.ContainerMethod:
+		sig.Context = m.HasContext
+		sig.Params = []Value{{Ident: "a0", Type: m.ContainerType}}
+		sig.Returns = []Value{{Ident: "stop", Type: "func()"}}
+		sig.ErrorType = "error"
+	default:
+		return sig, false
+	}
+
+	return sig, true
+}
+
+func values(prefix string, types []repository.NamedType) []Value {
+	var values = make([]Value, len(types))
+	for i, typ := range types {
+		values[i] = Value{
+			Ident: fmt.Sprintf("%s%d", prefix, i),
+			Type:  typ.Type,
+		}
+	}
+	return values
+}
+
+// IsContainer returns true if the method is a ContainerMethod.
+func (s Signature) IsContainer() bool {
+	_, ok := s.Method.Method.(repository.ContainerMethod)
+	return ok
+}
+
+// IsBlocking returns true if the method is an IOMethod or a ContainerMethod.
+func (s Signature) IsBlocking() bool {
+	switch s.Method.Method.(type) {
+	case repository.IOMethod, repository.ContainerMethod:
+		return true
+	default:
+		return false
+	}
+}
+
+// ParamList returns the parameters with their types, including the context.
+func (s Signature) ParamList() []jen.Code {
+	var params []jen.Code
+	if s.Context {
+		params = append(params, jen.Id("ctx").Qual("context", "Context"))
+	}
+	for _, param := range s.Params {
+		params = append(params, jen.Id(param.Ident).Add(s.typ(param.Type)))
+	}
+	return params
+}
+
+// ReturnTypes returns the types of all return values, including the error.
+func (s Signature) ReturnTypes() []jen.Code {
+	var types []jen.Code
+	for _, ret := range s.Returns {
+		types = append(types, s.typ(ret.Type))
+	}
+	if s.ErrorType != "" {
+		types = append(types, s.typ(s.ErrorType))
+	}
+	return types
+}
+
+// ReturnIdents returns the identifiers of all return values, including the
+// error.
+func (s Signature) ReturnIdents() []jen.Code {
+	var idents []jen.Code
+	for _, ret := range s.Returns {
+		idents = append(idents, jen.Id(ret.Ident))
+	}
+	if s.ErrorType != "" {
+		idents = append(idents, jen.Err())
+	}
+	return idents
+}
+
+// CallArgs returns the arguments to call the wrapped method with, including
+// the context. Parameters are wrapped.
+func (s Signature) CallArgs(w *Wrappers) []jen.Code {
+	var args []jen.Code
+	if s.Context {
+		args = append(args, jen.Id("ctx"))
+	}
+	for _, param := range s.Params {
+		args = append(args, w.Wrap(s.Path, jen.Id(param.Ident), param.Type))
+	}
+	return args
+}
+
+// WrappedReturns returns the return values with each of them wrapped, except
+// for the error.
+func (s Signature) WrappedReturns(w *Wrappers) []jen.Code {
+	var rets []jen.Code
+	for _, ret := range s.Returns {
+		rets = append(rets, w.Wrap(s.Path, jen.Id(ret.Ident), ret.Type))
+	}
+	if s.ErrorType != "" {
+		rets = append(rets, jen.Err())
+	}
+	return rets
+}
+
+// Func starts the method declaration with the given receiver type.
+func (s Signature) Func(recv string) *jen.Statement {
+	return jen.Func().Params(jen.Id("w").Id(recv)).Id(s.Name).
+		Params(s.ParamList()...).
+		Params(s.ReturnTypes()...)
+}
+
+// Call returns the code that calls the wrapped method.
+func (s Signature) Call(w *Wrappers) *jen.Statement {
+	return jen.Id("w").Dot("v").Dot(s.Name).Call(s.CallArgs(w)...)
+}
+
+func (s Signature) typ(typ string) jen.Code {
+	if typ == "func()" {
+		return jen.Func().Params()
+	}
+	return GenerateTypeName(s.Path, typ)
+}
@@ -0,0 +1,35 @@
+// Command cchat-idl-gen formats the IDL files in the current directory in
+// place. The IDL files are the source of repository.Main, which is generated
+// from them by cchat-main-gen.
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"log"
+	"path"
+
+	"github.com/diamondburned/cchat/repository"
+)
+
+func main() {
+	pkgs, err := repository.ParseIDLDir(".")
+	if err != nil {
+		log.Fatalln("Failed to parse IDL:", err)
+	}
+
+	for pkgPath, pkg := range pkgs {
+		// Print into a buffer first, so that a failure never truncates the
+		// source file.
+		var buf bytes.Buffer
+		if err := repository.PrintIDL(&buf, pkgPath, pkg); err != nil {
+			log.Fatalln("Failed to print IDL:", err)
+		}
+
+		output := path.Base(pkgPath) + repository.IDLExt
+
+		if err := ioutil.WriteFile(output, buf.Bytes(), 0644); err != nil {
+			log.Fatalln("Failed to write file:", err)
+		}
+	}
+}
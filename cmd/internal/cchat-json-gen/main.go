@@ -0,0 +1,27 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+
+	"github.com/diamondburned/cchat/repository"
+)
+
+const output = "repository.json"
+
+func main() {
+	f, err := os.Create(output)
+	if err != nil {
+		log.Fatalln("Failed to create file:", err)
+	}
+	defer f.Close()
+
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "\t")
+
+	if err := enc.Encode(repository.Main); err != nil {
+		os.Remove(output)
+		log.Fatalln("Failed to JSON encode:", err)
+	}
+}
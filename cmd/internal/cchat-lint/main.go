@@ -0,0 +1,23 @@
+// Command cchat-lint validates the cchat repository and prints all errors
+// found. It exits with a non-zero status code if there are any.
+package main
+
+import (
+	"log"
+	"os"
+
+	"github.com/diamondburned/cchat/repository"
+)
+
+func main() {
+	log.SetFlags(0)
+
+	errs := repository.Validate(repository.Main)
+	for _, err := range errs {
+		log.Println(err)
+	}
+
+	if len(errs) > 0 {
+		os.Exit(1)
+	}
+}
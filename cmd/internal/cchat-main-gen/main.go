@@ -0,0 +1,336 @@
+// Command cchat-main-gen generates repository.Main from the IDL files, which
+// are the source of the cchat repository.
+//
+// Usage
+//
+//    go run ./cmd/internal/cchat-main-gen ./repository/idl ./repository/main.go
+//
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/diamondburned/cchat/repository"
+)
+
+func init() {
+	log.SetFlags(0)
+}
+
+func main() {
+	if len(os.Args) != 3 {
+		log.Fatalln("Usage:", os.Args[0], "idl-dir output.go")
+	}
+
+	pkgs, err := repository.ParseIDLDir(os.Args[1])
+	if err != nil {
+		log.Fatalln("Failed to parse IDL:", err)
+	}
+
+	var p printer
+	p.printPackages(pkgs)
+
+	b, err := format.Source(p.buf.Bytes())
+	if err != nil {
+		log.Fatalln("Failed to format output:", err)
+	}
+
+	if err := ioutil.WriteFile(os.Args[2], b, 0644); err != nil {
+		log.Fatalln("Failed to write output:", err)
+	}
+}
+
+// printer prints the packages as Go source. The indentation is tracked only
+// for comments, since they are raw strings that gofmt cannot indent.
+type printer struct {
+	buf   bytes.Buffer
+	depth int
+}
+
+func (p *printer) printf(f string, v ...interface{}) {
+	fmt.Fprintf(&p.buf, f, v...)
+}
+
+// open prints the opening line and increments the depth.
+func (p *printer) open(f string, v ...interface{}) {
+	p.printf(f+"\n", v...)
+	p.depth++
+}
+
+// close decrements the depth and prints the closing line.
+func (p *printer) close(s string) {
+	p.depth--
+	p.printf("%s\n", s)
+}
+
+func (p *printer) comment(comment repository.Comment) {
+	text := comment.Text()
+	if text == "" {
+		return
+	}
+
+	// Comments are indented one level deeper than their field, which is how
+	// they are written in Go.
+	if strings.Contains(text, "`") {
+		p.printf("Comment: Comment{%s},\n", strconv.Quote(text))
+		return
+	}
+
+	indent := strings.Repeat("\t", p.depth+1)
+
+	p.printf("Comment: Comment{`\n")
+	for _, line := range strings.Split(text, "\n") {
+		if line != "" {
+			p.printf("%s%s", indent, line)
+		}
+		p.printf("\n")
+	}
+	p.printf("%s`},\n", indent[1:])
+}
+
+func (p *printer) field(name, value string) {
+	if value != "" {
+		p.printf("%s: %q,\n", name, value)
+	}
+}
+
+func (p *printer) flag(name string, value bool) {
+	if value {
+		p.printf("%s: true,\n", name)
+	}
+}
+
+func (p *printer) printPackages(pkgs repository.Packages) {
+	p.printf("// Code generated by ./cmd/internal. DO NOT EDIT.\n\n")
+	p.printf("package repository\n\n")
+
+	paths := make([]string, 0, len(pkgs))
+	for path := range pkgs {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	p.open("var Main = Packages{")
+
+	for _, path := range paths {
+		if path == repository.RootPath {
+			p.open("RootPath: {")
+		} else {
+			p.open("MakePath(%q): {", repository.TrimRoot(path))
+		}
+		p.printPackage(pkgs[path])
+		p.close("},")
+	}
+
+	p.close("}")
+}
+
+func (p *printer) printPackage(pkg repository.Package) {
+	p.comment(pkg.Comment)
+
+	if len(pkg.Enums) > 0 {
+		p.open("Enums: []Enumeration{")
+		for _, enum := range pkg.Enums {
+			p.printEnum(enum)
+		}
+		p.close("},")
+	}
+
+	if len(pkg.TypeAliases) > 0 {
+		p.open("TypeAliases: []TypeAlias{")
+		for _, alias := range pkg.TypeAliases {
+			p.open("{")
+			p.comment(alias.Comment)
+			p.printf("NamedType: %s,\n", namedType(alias.NamedType))
+			p.close("},")
+		}
+		p.close("},")
+	}
+
+	if len(pkg.Structs) > 0 {
+		p.open("Structs: []Struct{")
+		for _, sstruct := range pkg.Structs {
+			p.open("{")
+			p.printStruct(sstruct)
+			p.close("},")
+		}
+		p.close("},")
+	}
+
+	if len(pkg.ErrorStructs) > 0 {
+		p.open("ErrorStructs: []ErrorStruct{")
+		for _, estruct := range pkg.ErrorStructs {
+			p.open("{")
+			p.open("Struct: Struct{")
+			p.printStruct(estruct.Struct)
+			p.close("},")
+			p.printf("ErrorString: %s,\n", tmplString(estruct.ErrorString))
+			p.close("},")
+		}
+		p.close("},")
+	}
+
+	if len(pkg.Interfaces) > 0 {
+		p.open("Interfaces: []Interface{")
+		for _, iface := range pkg.Interfaces {
+			p.printInterface(iface)
+		}
+		p.close("},")
+	}
+}
+
+func (p *printer) printEnum(enum repository.Enumeration) {
+	p.open("{")
+	p.comment(enum.Comment)
+	p.field("Name", enum.Name)
+
+	p.open("Values: []EnumValue{")
+	for _, value := range enum.Values {
+		p.open("{")
+		p.comment(value.Comment)
+		p.field("Name", value.Name)
+		p.close("},")
+	}
+	p.close("},")
+
+	p.flag("Bitwise", enum.Bitwise)
+	p.close("},")
+}
+
+func (p *printer) printStruct(sstruct repository.Struct) {
+	p.comment(sstruct.Comment)
+	p.field("Name", sstruct.Name)
+
+	if len(sstruct.Fields) > 0 {
+		p.open("Fields: []StructField{")
+		for _, field := range sstruct.Fields {
+			p.open("{")
+			p.comment(field.Comment)
+			p.printf("NamedType: %s,\n", namedType(field.NamedType))
+			p.close("},")
+		}
+		p.close("},")
+	}
+
+	stringer := sstruct.Stringer
+	if !stringer.Comment.IsEmpty() || !stringer.TmplString.IsEmpty() {
+		p.open("Stringer: Stringer{")
+		p.comment(stringer.Comment)
+		p.printf("TmplString: %s,\n", tmplString(stringer.TmplString))
+		p.close("},")
+	}
+}
+
+func (p *printer) printInterface(iface repository.Interface) {
+	p.open("{")
+	p.comment(iface.Comment)
+	p.field("Name", iface.Name)
+
+	if len(iface.Embeds) > 0 {
+		p.open("Embeds: []EmbeddedInterface{")
+		for _, embed := range iface.Embeds {
+			p.open("{")
+			p.comment(embed.Comment)
+			p.field("InterfaceName", embed.InterfaceName)
+			p.close("},")
+		}
+		p.close("},")
+	}
+
+	if len(iface.Methods) > 0 {
+		p.open("Methods: []Method{")
+		for _, method := range iface.Methods {
+			p.printMethod(method)
+		}
+		p.close("},")
+	}
+
+	p.close("},")
+}
+
+func (p *printer) printMethod(method repository.Method) {
+	if asserter, ok := method.(repository.AsserterMethod); ok {
+		p.printf("AsserterMethod{ChildType: %q},\n", asserter.ChildType)
+		return
+	}
+
+	p.open("%s{", repository.MethodKind(method))
+
+	p.open("method: method{")
+	p.comment(method.UnderlyingComment())
+	p.field("Name", method.UnderlyingName())
+	p.close("},")
+
+	switch method := method.(type) {
+	case repository.GetterMethod:
+		p.namedTypes("Parameters", method.Parameters)
+		p.namedTypes("Returns", method.Returns)
+		p.field("ErrorType", method.ErrorType)
+
+	case repository.SetterMethod:
+		p.namedTypes("Parameters", method.Parameters)
+		p.field("ErrorType", method.ErrorType)
+
+	case repository.ContainerUpdaterMethod:
+		p.namedTypes("Parameters", method.Parameters)
+		p.field("ErrorType", method.ErrorType)
+
+	case repository.IOMethod:
+		p.namedTypes("Parameters", method.Parameters)
+		if method.ReturnValue != (repository.NamedType{}) {
+			p.printf("ReturnValue: %s,\n", namedType(method.ReturnValue))
+		}
+		p.field("ErrorType", method.ErrorType)
+		p.flag("Disposer", method.Disposer)
+
+	case repository.ContainerMethod:
+		p.flag("HasContext", method.HasContext)
+		p.field("ContainerType", method.ContainerType)
+
+	default:
+		log.Fatalf("Unknown method type %T", method)
+	}
+
+	p.close("},")
+}
+
+func (p *printer) namedTypes(name string, types []repository.NamedType) {
+	if len(types) == 0 {
+		return
+	}
+
+	p.open("%s: []NamedType{", name)
+	for _, typ := range types {
+		p.printf("%s,\n", namedType(typ)[len("NamedType"):])
+	}
+	p.close("},")
+}
+
+func namedType(typ repository.NamedType) string {
+	if typ.Name == "" {
+		return fmt.Sprintf("NamedType{Type: %q}", typ.Type)
+	}
+	return fmt.Sprintf("NamedType{%q, %q}", typ.Name, typ.Type)
+}
+
+func tmplString(tmpl repository.TmplString) string {
+	if len(tmpl.Fields) == 0 {
+		return fmt.Sprintf("TmplString{Format: %q}", tmpl.Format)
+	}
+
+	fields := make([]string, len(tmpl.Fields))
+	for i, field := range tmpl.Fields {
+		fields[i] = strconv.Quote(field)
+	}
+
+	return fmt.Sprintf("TmplString{Format: %q, Fields: []string{%s}}",
+		tmpl.Format, strings.Join(fields, ", "))
+}
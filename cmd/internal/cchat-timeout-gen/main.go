@@ -0,0 +1,244 @@
+package main
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dave/jennifer/jen"
+	"github.com/diamondburned/cchat/cmd/internal/cchat-generator/genutils"
+	"github.com/diamondburned/cchat/repository"
+)
+
+func init() {
+	log.SetFlags(0)
+}
+
+func main() {
+	w := genutils.NewWrappers(genutils.NewFile("timeout"), jen.Op("*").Id("Timeouts"))
+
+	var blocking []jen.Code
+
+	for _, wrapped := range w.Wrapped {
+		w.GenerateType(wrapped,
+			wrapped.FuncName()+" wraps v to enforce the deadlines in t on its blocking methods.",
+			"Nil is returned if v is nil.",
+		)
+
+		var container = strings.HasSuffix(wrapped.Name, "Container")
+
+		for _, method := range wrapped.Methods {
+			sig, ok := genutils.NewSignature(method)
+			switch {
+			case !ok:
+				w.GenerateAsserter(wrapped, method)
+			case container && !sig.IsBlocking():
+				genContainer(w, wrapped, sig)
+			case sig.IsBlocking() && sig.ErrorType != "":
+				// Embedded methods are only listed once, under the interface
+				// that declares them.
+				if method.Path == wrapped.Path && method.Interface == wrapped.Name {
+					blocking = append(blocking, jen.Lit(method.GoName()))
+				}
+				genBlocking(w, wrapped, sig)
+			default:
+				// Blocking methods without an error type cannot report being
+				// abandoned, so they are not given a deadline.
+				genPassthrough(w, wrapped, sig)
+			}
+			w.Line()
+		}
+	}
+
+	w.GenerateSlices()
+
+	w.Comment("BlockingMethods contains the keys of all methods that Timeouts applies to.")
+	w.Var().Id("BlockingMethods").Op("=").Index().String().
+		Values(jen.ListFunc(func(g *jen.Group) {
+			for _, method := range blocking {
+				g.Line().Add(method)
+			}
+			g.Line()
+		}))
+
+	f, err := os.Create(filepath.Join(os.Args[1], "timeout_gen.go"))
+	if err != nil {
+		log.Fatalln("Failed to create output file:", err)
+	}
+	defer f.Close()
+
+	if err := w.Render(f); err != nil {
+		log.Fatalln("Failed to render output:", err)
+	}
+}
+
+func genPassthrough(w *genutils.Wrappers, wrapped genutils.Wrapped, sig genutils.Signature) {
+	w.Add(sig.Func(wrapped.TypeName()).BlockFunc(func(g *jen.Group) {
+		var returns = sig.ReturnIdents()
+		if len(returns) == 0 {
+			g.Add(sig.Call(w))
+			return
+		}
+
+		g.List(returns...).Op(":=").Add(sig.Call(w))
+		g.Return(sig.WrappedReturns(w)...)
+	}))
+}
+
+// genContainer generates a container method that is dropped once the call
+// that the container was given to is abandoned.
+func genContainer(w *genutils.Wrappers, wrapped genutils.Wrapped, sig genutils.Signature) {
+	if len(sig.ReturnTypes()) > 0 {
+		log.Fatalln("Unsupported container method with return values:", sig.Method.GoName())
+	}
+
+	w.Add(sig.Func(wrapped.TypeName()).Block(
+		jen.If(jen.Id("w").Dot("t").Dot("dropped").Call(jen.Lit(sig.Method.GoName()))).
+			Block(jen.Return()),
+		sig.Call(w),
+	))
+}
+
+func genBlocking(w *genutils.Wrappers, wrapped genutils.Wrapped, sig genutils.Signature) {
+	var key = sig.Method.GoName()
+
+	// Methods without a context can still be abandoned.
+	var ctx jen.Code = jen.Id("ctx")
+	var ctxIdent = jen.Id("ctx")
+	if !sig.Context {
+		ctx = jen.Qual("context", "Background").Call()
+		ctxIdent = jen.Id("_")
+	}
+
+	// The late callback releases what the backend acquired for an abandoned
+	// container method, or disposes the interfaces returned late, such as
+	// Sessions from Authenticate.
+	var release []jen.Code
+	if sig.IsContainer() {
+		release = append(release,
+			jen.If(jen.Id("stop").Op("!=").Nil()).Block(jen.Id("stop").Call()),
+		)
+	}
+	for _, ret := range sig.Returns {
+		if dispose, ok := disposer(sig.Path, ret.Type); ok {
+			release = append(release,
+				jen.If(jen.Id(ret.Ident).Op("!=").Nil()).Block(
+					jen.Id(ret.Ident).Dot(dispose).Call(jen.Qual("context", "Background").Call()),
+				),
+			)
+		}
+	}
+
+	var late jen.Code = jen.Nil()
+	if len(release) > 0 {
+		late = jen.Func().Params().Block(release...)
+	}
+
+	var zeros []jen.Code
+	for _, ret := range sig.Returns {
+		zeros = append(zeros, zeroValue(sig.Path, ret.Type))
+	}
+	zeros = append(zeros, abandonErr(sig.ErrorType))
+
+	w.Add(sig.Func(wrapped.TypeName()).BlockFunc(func(g *jen.Group) {
+		g.List(ctxIdent, jen.Id("c")).Op(":=").Id("w").Dot("t").Dot("begin").Call(ctx, jen.Lit(key))
+		g.Id("w").Dot("t").Op("=").Id("c").Dot("scope").Call()
+
+		for _, ret := range sig.Returns {
+			g.Var().Id(ret.Ident).Add(genutils.GenerateTypeName(sig.Path, ret.Type))
+		}
+		g.Var().Err().Add(genutils.GenerateTypeName(sig.Path, sig.ErrorType))
+
+		// The return values must not be read if the call is abandoned, since
+		// the backend may still write them.
+		g.If(
+			jen.Id("cerr").Op(":=").Id("c").Dot("run").Call(
+				jen.Func().Params().Block(
+					jen.List(sig.ReturnIdents()...).Op("=").Add(sig.Call(w)),
+				),
+				late,
+			),
+			jen.Id("cerr").Op("!=").Nil(),
+		).Block(jen.Return(zeros...))
+
+		g.Return(sig.WrappedReturns(w)...)
+	}))
+}
+
+// abandonErr returns the code that converts cerr to the method's error type.
+func abandonErr(errType string) jen.Code {
+	switch errType {
+	case "error":
+		return jen.Id("cerr")
+	case "AuthenticateError":
+		return jen.Qual(repository.RootPath, "WrapAuthenticateError").Call(jen.Id("cerr"))
+	default:
+		log.Fatalln("Unsupported error type of blocking method:", errType)
+		return nil
+	}
+}
+
+// disposer returns the name of the disposer method of the given interface type,
+// which must take only a context. False is returned if the type is not an
+// interface or has no such method.
+func disposer(pkgPath, typ string) (string, bool) {
+	typePath, name := repository.TypeQual(typ)
+	if typePath == "" {
+		typePath = pkgPath
+	}
+
+	iface := repository.Main[typePath].Interface(name)
+	if iface == nil {
+		return "", false
+	}
+
+	methods, ok := genutils.InterfaceMethods(repository.Main, typePath, *iface)
+	if !ok {
+		return "", false
+	}
+
+	for _, method := range methods {
+		io, ok := method.Method.(repository.IOMethod)
+		if ok && io.Disposer && len(io.Parameters) == 0 {
+			return io.Name, true
+		}
+	}
+
+	return "", false
+}
+
+// zeroValue returns the zero value of the given type.
+func zeroValue(pkgPath, typ string) jen.Code {
+	if strings.HasPrefix(typ, "[]") || strings.HasPrefix(typ, "*") || typ == "func()" {
+		return jen.Nil()
+	}
+
+	switch typ {
+	case "bool":
+		return jen.False()
+	case "string":
+		return jen.Lit("")
+	case "int", "int64", "uint", "uint32", "uint64", "float64":
+		return jen.Lit(0)
+	}
+
+	typePath, name := repository.TypeQual(typ)
+	if typePath == "" {
+		typePath = pkgPath
+	}
+
+	switch t := repository.Main[typePath].FindType(name).(type) {
+	case *repository.Interface:
+		return jen.Nil()
+	case *repository.Struct:
+		return jen.Qual(typePath, name).Values()
+	case *repository.Enumeration:
+		return jen.Lit(0)
+	case *repository.TypeAlias:
+		return zeroValue(typePath, t.Type)
+	default:
+		log.Fatalln("Unknown zero value of type:", typ)
+		return nil
+	}
+}
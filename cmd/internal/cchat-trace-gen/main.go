@@ -0,0 +1,121 @@
+package main
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/dave/jennifer/jen"
+	"github.com/diamondburned/cchat/cmd/internal/cchat-generator/genutils"
+)
+
+func init() {
+	log.SetFlags(0)
+}
+
+// redacted contains the methods whose arguments and non-error return values
+// are replaced with Redacted in events, since they may contain credentials.
+var redacted = map[string]bool{
+	"cchat.Authenticator.Authenticate":     true,
+	"cchat.SessionRestorer.RestoreSession": true,
+	"cchat.SessionSaver.SaveSession":       true,
+	"cchat.Configurator.Configuration":     true,
+	"cchat.Configurator.SetConfiguration":  true,
+}
+
+func main() {
+	w := genutils.NewWrappers(genutils.NewFile("trace"), jen.Id("Tracer"))
+
+	for _, wrapped := range w.Wrapped {
+		w.GenerateType(wrapped,
+			wrapped.FuncName()+" wraps v to trace its method calls with t.",
+			"Nil is returned if v is nil.",
+		)
+
+		for _, method := range wrapped.Methods {
+			genMethod(w, wrapped, method)
+			w.Line()
+		}
+	}
+
+	w.GenerateSlices()
+
+	f, err := os.Create(filepath.Join(os.Args[1], "trace_gen.go"))
+	if err != nil {
+		log.Fatalln("Failed to create output file:", err)
+	}
+	defer f.Close()
+
+	if err := w.Render(f); err != nil {
+		log.Fatalln("Failed to render output:", err)
+	}
+}
+
+func genMethod(w *genutils.Wrappers, wrapped genutils.Wrapped, method genutils.Method) {
+	sig, ok := genutils.NewSignature(method)
+	if !ok {
+		w.GenerateAsserter(wrapped, method)
+		return
+	}
+
+	var ifaceName = wrapped.GoName()
+	var recv = wrapped.TypeName()
+
+	if sig.IsContainer() {
+		w.Add(sig.Func(recv).Block(
+			jen.Id("c").Op(":=").Id("beginBlocking").Call(
+				jen.Id("w").Dot("t"), jen.Lit(ifaceName), jen.Lit(sig.Name), jen.Id("a0"),
+			),
+			jen.List(jen.Id("stop"), jen.Err()).Op(":=").Add(sig.Call(w)),
+			jen.Id("c").Dot("end").Call(jen.Err()),
+			jen.Return(
+				jen.Id("stopFunc").Call(
+					jen.Id("w").Dot("t"), jen.Lit(ifaceName), jen.Lit(sig.Name), jen.Id("stop"),
+				),
+				jen.Err(),
+			),
+		))
+		return
+	}
+
+	var redact = redacted[ifaceName+"."+sig.Name]
+	traced := func(ident string) jen.Code {
+		if redact {
+			return jen.Id("Redacted")
+		}
+		return jen.Id(ident)
+	}
+
+	var traceArgs = []jen.Code{jen.Id("w").Dot("t"), jen.Lit(ifaceName), jen.Lit(sig.Name)}
+	for _, param := range sig.Params {
+		traceArgs = append(traceArgs, traced(param.Ident))
+	}
+
+	var traceReturns []jen.Code
+	for _, ret := range sig.Returns {
+		traceReturns = append(traceReturns, traced(ret.Ident))
+	}
+	if sig.ErrorType != "" {
+		traceReturns = append(traceReturns, jen.Err())
+	}
+
+	var begin = "begin"
+	if sig.IsBlocking() {
+		begin = "beginBlocking"
+	}
+
+	w.Add(sig.Func(recv).BlockFunc(func(g *jen.Group) {
+		g.Id("c").Op(":=").Id(begin).Call(traceArgs...)
+
+		var returns = sig.ReturnIdents()
+		if len(returns) == 0 {
+			g.Add(sig.Call(w))
+			g.Id("c").Dot("end").Call()
+			return
+		}
+
+		g.List(returns...).Op(":=").Add(sig.Call(w))
+		g.Id("c").Dot("end").Call(traceReturns...)
+		g.Return(sig.WrappedReturns(w)...)
+	}))
+}
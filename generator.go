@@ -1,7 +1,13 @@
 package cchat
 
+//go:generate go run ./cmd/internal/cchat-main-gen ./repository/idl ./repository/main.go
+//go:generate go run ./cmd/internal/cchat-lint
 //go:generate go run ./cmd/internal/cchat-generator ./
 //go:generate go run ./cmd/internal/cchat-empty-gen ./utils/empty/
+//go:generate go run ./cmd/internal/cchat-caps-gen ./utils/caps/
+//go:generate go run ./cmd/internal/cchat-trace-gen ./utils/trace/
+//go:generate go run ./cmd/internal/cchat-timeout-gen ./utils/timeout/
+//go:generate go run ./cmd/internal/cchat-contract-gen ./utils/contract/
 
 type authenticateError struct{ error }
 
@@ -110,3 +110,31 @@ func (c Comment) Unindent() string {
 
 	return txt
 }
+
+// Text returns the comment's text without the indentation that is common to
+// all of its lines and without surrounding blank lines. Unlike Unindent, which
+// keeps a level of indentation for go/doc, this is suitable for tools outside
+// of Go.
+func (c Comment) Text() string {
+	var lines = strings.Split(c.Unindent(), "\n")
+	var indent = -1
+
+	for _, line := range lines {
+		if line == "" {
+			continue
+		}
+		if linedent := len(line) - len(strings.TrimLeft(line, "\t")); indent == -1 || linedent < indent {
+			indent = linedent
+		}
+	}
+
+	if indent > 0 {
+		for i, line := range lines {
+			if line != "" {
+				lines[i] = line[indent:]
+			}
+		}
+	}
+
+	return strings.Trim(strings.Join(lines, "\n"), "\n")
+}
@@ -0,0 +1,371 @@
+package repository
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Change describes a single difference between two snapshots of Packages.
+type Change struct {
+	Path        string // package path
+	Description string
+	// Breaking is true if the change would break existing implementations or
+	// users of the API, such as when a method is removed or has its signature
+	// changed.
+	Breaking bool
+}
+
+// String formats the change into a single line prefixed with either
+// "breaking" or "compatible".
+func (c Change) String() string {
+	var kind = "compatible"
+	if c.Breaking {
+		kind = "breaking"
+	}
+
+	return fmt.Sprintf("%s: %s: %s", kind, trimRootOrPath(c.Path), c.Description)
+}
+
+// Compare compares the old and new packages and returns the list of changes in
+// a stable order. Changes that only affect comments are ignored.
+//
+// Additions are compatible, except for new methods that aren't asserters on
+// existing interfaces, since existing implementations will no longer satisfy
+// them. New asserters are compatible, because implementations are expected to
+// embed the types in package empty, which will return nil for them.
+func Compare(old, new Packages) []Change {
+	var c comparer
+
+	paths := make([]string, 0, len(old)+len(new))
+	for path := range old {
+		paths = append(paths, path)
+	}
+	for path := range new {
+		if _, ok := old[path]; !ok {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		oldPkg, oldOK := old[path]
+		newPkg, newOK := new[path]
+
+		switch {
+		case !newOK:
+			c.breaking(path, "package removed")
+		case !oldOK:
+			c.compatible(path, "package added")
+		default:
+			c.comparePackage(path, oldPkg, newPkg)
+		}
+	}
+
+	return c.changes
+}
+
+// HasBreaking returns true if any of the given changes are breaking.
+func HasBreaking(changes []Change) bool {
+	for _, change := range changes {
+		if change.Breaking {
+			return true
+		}
+	}
+	return false
+}
+
+type comparer struct {
+	changes []Change
+}
+
+func (c *comparer) breaking(path, f string, v ...interface{}) {
+	c.changes = append(c.changes, Change{
+		Path:        path,
+		Description: fmt.Sprintf(f, v...),
+		Breaking:    true,
+	})
+}
+
+func (c *comparer) compatible(path, f string, v ...interface{}) {
+	c.changes = append(c.changes, Change{
+		Path:        path,
+		Description: fmt.Sprintf(f, v...),
+		Breaking:    false,
+	})
+}
+
+func (c *comparer) comparePackage(path string, old, new Package) {
+	for _, oldEnum := range old.Enums {
+		newEnum := new.Enum(oldEnum.Name)
+		if newEnum == nil {
+			c.breaking(path, "enum %s removed", oldEnum.Name)
+			continue
+		}
+		c.compareEnum(path, oldEnum, *newEnum)
+	}
+	for _, newEnum := range new.Enums {
+		if old.Enum(newEnum.Name) == nil {
+			c.compatible(path, "enum %s added", newEnum.Name)
+		}
+	}
+
+	for _, oldAlias := range old.TypeAliases {
+		newAlias := new.TypeAlias(oldAlias.Name)
+		switch {
+		case newAlias == nil:
+			c.breaking(path, "type %s removed", oldAlias.Name)
+		case newAlias.Type != oldAlias.Type:
+			c.breaking(path, "type %s changed from %s to %s",
+				oldAlias.Name, oldAlias.Type, newAlias.Type)
+		}
+	}
+	for _, newAlias := range new.TypeAliases {
+		if old.TypeAlias(newAlias.Name) == nil {
+			c.compatible(path, "type %s added", newAlias.Name)
+		}
+	}
+
+	for _, oldStruct := range old.Structs {
+		newStruct := new.Struct(oldStruct.Name)
+		if newStruct == nil {
+			c.breaking(path, "struct %s removed", oldStruct.Name)
+			continue
+		}
+		c.compareStruct(path, oldStruct, *newStruct)
+	}
+	for _, oldStruct := range old.ErrorStructs {
+		newStruct := new.Struct(oldStruct.Name)
+		if newStruct == nil {
+			c.breaking(path, "error struct %s removed", oldStruct.Name)
+			continue
+		}
+		c.compareStruct(path, oldStruct.Struct, *newStruct)
+	}
+	for _, newStruct := range new.Structs {
+		if old.Struct(newStruct.Name) == nil {
+			c.compatible(path, "struct %s added", newStruct.Name)
+		}
+	}
+	for _, newStruct := range new.ErrorStructs {
+		if old.Struct(newStruct.Name) == nil {
+			c.compatible(path, "error struct %s added", newStruct.Name)
+		}
+	}
+
+	for _, oldIface := range old.Interfaces {
+		newIface := new.Interface(oldIface.Name)
+		if newIface == nil {
+			c.breaking(path, "interface %s removed", oldIface.Name)
+			continue
+		}
+		c.compareInterface(path, oldIface, *newIface)
+	}
+	for _, newIface := range new.Interfaces {
+		if old.Interface(newIface.Name) == nil {
+			c.compatible(path, "interface %s added", newIface.Name)
+		}
+	}
+}
+
+func (c *comparer) compareEnum(path string, old, new Enumeration) {
+	if old.GoType() != new.GoType() {
+		c.breaking(path, "enum %s changed type from %s to %s",
+			old.Name, old.GoType(), new.GoType())
+	}
+
+	// Values are constants generated with iota, so they must stay in the same
+	// order.
+	for i, oldValue := range old.Values {
+		if i >= len(new.Values) {
+			c.breaking(path, "enum %s value %s removed", old.Name, oldValue.Name)
+			continue
+		}
+		if newValue := new.Values[i]; newValue.Name != oldValue.Name {
+			c.breaking(path, "enum %s value %d changed from %s to %s",
+				old.Name, i, oldValue.Name, newValue.Name)
+		}
+	}
+	for i := len(old.Values); i < len(new.Values); i++ {
+		c.compatible(path, "enum %s value %s added", new.Name, new.Values[i].Name)
+	}
+}
+
+func (c *comparer) compareStruct(path string, old, new Struct) {
+	for _, oldField := range old.Fields {
+		newField := findField(new.Fields, oldField.Name)
+		switch {
+		case newField == nil:
+			c.breaking(path, "struct %s field %s removed", old.Name, oldField.Name)
+		case newField.Type != oldField.Type:
+			c.breaking(path, "struct %s field %s changed from %s to %s",
+				old.Name, oldField.Name, oldField.Type, newField.Type)
+		}
+	}
+	for _, newField := range new.Fields {
+		if findField(old.Fields, newField.Name) == nil {
+			c.compatible(path, "struct %s field %s added", new.Name, newField.Name)
+		}
+	}
+}
+
+func findField(fields []StructField, name string) *StructField {
+	for i, field := range fields {
+		if field.Name == name {
+			return &fields[i]
+		}
+	}
+	return nil
+}
+
+func (c *comparer) compareInterface(path string, old, new Interface) {
+	for _, oldEmbed := range old.Embeds {
+		if findEmbed(new.Embeds, oldEmbed.InterfaceName) == nil {
+			c.breaking(path, "interface %s no longer embeds %s",
+				old.Name, oldEmbed.InterfaceName)
+		}
+	}
+	for _, newEmbed := range new.Embeds {
+		if findEmbed(old.Embeds, newEmbed.InterfaceName) == nil {
+			c.breaking(path, "interface %s now embeds %s",
+				new.Name, newEmbed.InterfaceName)
+		}
+	}
+
+	for _, oldMethod := range old.Methods {
+		name := oldMethod.UnderlyingName()
+
+		newMethod := findMethod(new.Methods, name)
+		if newMethod == nil {
+			c.breaking(path, "method %s.%s removed", old.Name, name)
+			continue
+		}
+
+		oldKind, newKind := MethodKind(oldMethod), MethodKind(newMethod)
+		if oldKind != newKind {
+			c.breaking(path, "method %s.%s changed from %s to %s",
+				old.Name, name, oldKind, newKind)
+			continue
+		}
+
+		if oldSig, newSig := methodSignature(oldMethod), methodSignature(newMethod); oldSig != newSig {
+			c.breaking(path, "method %s.%s changed signature from %s to %s",
+				old.Name, name, oldSig, newSig)
+		}
+	}
+	for _, newMethod := range new.Methods {
+		name := newMethod.UnderlyingName()
+		if findMethod(old.Methods, name) != nil {
+			continue
+		}
+
+		if _, ok := newMethod.(AsserterMethod); ok {
+			c.compatible(path, "asserter %s.%s added", new.Name, name)
+		} else {
+			c.breaking(path, "method %s.%s added", new.Name, name)
+		}
+	}
+}
+
+func findEmbed(embeds []EmbeddedInterface, name string) *EmbeddedInterface {
+	for i, embed := range embeds {
+		if embed.InterfaceName == name {
+			return &embeds[i]
+		}
+	}
+	return nil
+}
+
+func findMethod(methods []Method, name string) Method {
+	for _, method := range methods {
+		if method.UnderlyingName() == name {
+			return method
+		}
+	}
+	return nil
+}
+
+// MethodKind returns the name of the method's type, such as "IOMethod".
+func MethodKind(method Method) string {
+	switch method.(type) {
+	case GetterMethod:
+		return "GetterMethod"
+	case SetterMethod:
+		return "SetterMethod"
+	case ContainerUpdaterMethod:
+		return "ContainerUpdaterMethod"
+	case IOMethod:
+		return "IOMethod"
+	case ContainerMethod:
+		return "ContainerMethod"
+	case AsserterMethod:
+		return "AsserterMethod"
+	default:
+		return fmt.Sprintf("%T", method)
+	}
+}
+
+// methodSignature returns a string describing the parameter and return types of
+// the method. Parameter names are ignored.
+func methodSignature(method Method) string {
+	switch method := method.(type) {
+	case GetterMethod:
+		return signature(method.Parameters, method.Returns, method.ErrorType)
+	case SetterMethod:
+		return signature(method.Parameters, nil, method.ErrorType)
+	case ContainerUpdaterMethod:
+		return signature(method.Parameters, nil, method.ErrorType)
+	case IOMethod:
+		var returns []NamedType
+		if !method.ReturnValue.IsZero() {
+			returns = []NamedType{method.ReturnValue}
+		}
+		return signature(method.Parameters, returns, method.ErrorType)
+	case ContainerMethod:
+		var params []NamedType
+		if method.HasContext {
+			params = append(params, NamedType{Type: "context.Context"})
+		}
+		params = append(params, NamedType{Type: method.ContainerType})
+		return signature(params, []NamedType{{Type: "func()"}}, "error")
+	case AsserterMethod:
+		return signature(nil, []NamedType{{Type: method.ChildType}}, "")
+	default:
+		return ""
+	}
+}
+
+func signature(params, returns []NamedType, errorType string) string {
+	var builder strings.Builder
+
+	builder.WriteByte('(')
+	for i, param := range params {
+		if i > 0 {
+			builder.WriteString(", ")
+		}
+		builder.WriteString(param.Type)
+	}
+	builder.WriteByte(')')
+
+	if errorType != "" {
+		returns = append(returns[:len(returns):len(returns)], NamedType{Type: errorType})
+	}
+
+	switch len(returns) {
+	case 0:
+	case 1:
+		builder.WriteByte(' ')
+		builder.WriteString(returns[0].Type)
+	default:
+		builder.WriteString(" (")
+		for i, ret := range returns {
+			if i > 0 {
+				builder.WriteString(", ")
+			}
+			builder.WriteString(ret.Type)
+		}
+		builder.WriteByte(')')
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"encoding/gob"
+	"os"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestCompareSnapshot(t *testing.T) {
+	f, err := os.Open("gob/repository.gob")
+	if err != nil {
+		t.Fatal("Failed to open snapshot:", err)
+	}
+	defer f.Close()
+
+	var snapshot Packages
+
+	if err := gob.NewDecoder(f).Decode(&snapshot); err != nil {
+		t.Fatal("Failed to gob decode:", err)
+	}
+
+	for _, change := range Compare(snapshot, Main) {
+		t.Error("Snapshot is outdated:", change)
+	}
+}
+
+func TestCompareLegacySnapshot(t *testing.T) {
+	f, err := os.Open("testdata/legacy.gob")
+	if err != nil {
+		t.Fatal("Failed to open snapshot:", err)
+	}
+	defer f.Close()
+
+	// Legacy snapshots cannot be decoded as usual.
+	if err := gob.NewDecoder(f).Decode(&Packages{}); err == nil {
+		t.Fatal("Unexpected success decoding legacy snapshot")
+	}
+	f.Seek(0, 0)
+
+	snapshot, err := DecodeLegacyGob(f)
+	if err != nil {
+		t.Fatal("Failed to decode legacy snapshot:", err)
+	}
+
+	iface := snapshot[RootPath].Interface("Service")
+	if iface == nil || len(iface.Embeds) == 0 {
+		t.Fatalf("Unexpected Service interface: %#v", iface)
+	}
+
+	for _, change := range Compare(snapshot, StripMethods(Main)) {
+		if change.Breaking {
+			t.Error("Unexpected breaking change:", change)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	var old = Packages{
+		RootPath: {
+			Enums: []Enumeration{{
+				Name:   "Status",
+				Values: []EnumValue{{Name: "Online"}, {Name: "Offline"}},
+			}},
+			Structs: []Struct{{
+				Name: "Draft",
+				Fields: []StructField{
+					{NamedType: NamedType{Name: "Content", Type: "string"}},
+				},
+			}},
+			Interfaces: []Interface{{
+				Name: "Messenger",
+				Methods: []Method{
+					GetterMethod{
+						method:  method{Name: "ID"},
+						Returns: []NamedType{{Type: "ID"}},
+					},
+					IOMethod{
+						method:     method{Name: "Send"},
+						Parameters: []NamedType{{Name: "content", Type: "string"}},
+						ErrorType:  "error",
+					},
+					SetterMethod{method: method{Name: "Reset"}},
+				},
+			}, {
+				Name: "Removed",
+			}},
+		},
+	}
+
+	var new = Packages{
+		RootPath: {
+			Enums: []Enumeration{{
+				Name:   "Status",
+				Values: []EnumValue{{Name: "Online"}, {Name: "Offline"}, {Name: "Idle"}},
+			}},
+			Structs: []Struct{{
+				Name: "Draft",
+				Fields: []StructField{
+					{NamedType: NamedType{Name: "Content", Type: "text.Rich"}},
+					{NamedType: NamedType{Name: "ReplyingTo", Type: "ID"}},
+				},
+			}},
+			Interfaces: []Interface{{
+				Name: "Messenger",
+				Methods: []Method{
+					GetterMethod{
+						method:  method{Name: "ID"},
+						Returns: []NamedType{{Type: "ID"}},
+					},
+					IOMethod{
+						method:     method{Name: "Send"},
+						Parameters: []NamedType{{Name: "msg", Type: "SendableMessage"}},
+						ErrorType:  "error",
+					},
+					IOMethod{method: method{Name: "Reset"}, ErrorType: "error"},
+					GetterMethod{method: method{Name: "Name"}},
+					AsserterMethod{ChildType: "Sender"},
+				},
+			}, {
+				Name: "Sender",
+			}},
+		},
+	}
+
+	var expect = []string{
+		"compatible: github.com/diamondburned/cchat: enum Status value Idle added",
+		"breaking: github.com/diamondburned/cchat: struct Draft field Content changed from string to text.Rich",
+		"compatible: github.com/diamondburned/cchat: struct Draft field ReplyingTo added",
+		"breaking: github.com/diamondburned/cchat: method Messenger.Send changed signature from (string) error to (SendableMessage) error",
+		"breaking: github.com/diamondburned/cchat: method Messenger.Reset changed from SetterMethod to IOMethod",
+		"breaking: github.com/diamondburned/cchat: method Messenger.Name added",
+		"compatible: github.com/diamondburned/cchat: asserter Messenger.AsSender added",
+		"breaking: github.com/diamondburned/cchat: interface Removed removed",
+		"compatible: github.com/diamondburned/cchat: interface Sender added",
+	}
+
+	var changes = Compare(old, new)
+	var got = make([]string, len(changes))
+	for i, change := range changes {
+		got[i] = change.String()
+	}
+
+	if eq := deep.Equal(expect, got); eq != nil {
+		t.Fatal("Unexpected changes:", eq)
+	}
+
+	if !HasBreaking(changes) {
+		t.Fatal("Expected breaking changes.")
+	}
+}
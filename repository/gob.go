@@ -0,0 +1,180 @@
+package repository
+
+import (
+	"bytes"
+	"encoding/gob"
+	"io"
+)
+
+// gobMethod is the exported form of the embedded method struct. Gob ignores
+// unexported fields, so without this, method names and comments would be lost
+// in the gob snapshot.
+type gobMethod struct {
+	Comment Comment
+	Name    string
+}
+
+// encodeMethod encodes the method's name and comment followed by v, which
+// should be the method with its GobEncode method stripped off.
+func encodeMethod(m method, v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := gob.NewEncoder(&buf)
+
+	if err := enc.Encode(gobMethod{Comment: m.Comment, Name: m.Name}); err != nil {
+		return nil, err
+	}
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// decodeMethod decodes what was encoded by encodeMethod into m and v.
+func decodeMethod(b []byte, m *method, v interface{}) error {
+	dec := gob.NewDecoder(bytes.NewReader(b))
+
+	var gm gobMethod
+	if err := dec.Decode(&gm); err != nil {
+		return err
+	}
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+
+	m.Comment = gm.Comment
+	m.Name = gm.Name
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder.
+func (m GetterMethod) GobEncode() ([]byte, error) {
+	type raw GetterMethod
+	return encodeMethod(m.method, raw(m))
+}
+
+// GobDecode implements gob.GobDecoder.
+func (m *GetterMethod) GobDecode(b []byte) error {
+	type raw GetterMethod
+	return decodeMethod(b, &m.method, (*raw)(m))
+}
+
+// GobEncode implements gob.GobEncoder.
+func (m SetterMethod) GobEncode() ([]byte, error) {
+	type raw SetterMethod
+	return encodeMethod(m.method, raw(m))
+}
+
+// GobDecode implements gob.GobDecoder.
+func (m *SetterMethod) GobDecode(b []byte) error {
+	type raw SetterMethod
+	return decodeMethod(b, &m.method, (*raw)(m))
+}
+
+// GobEncode implements gob.GobEncoder.
+func (m ContainerUpdaterMethod) GobEncode() ([]byte, error) {
+	type raw ContainerUpdaterMethod
+	return encodeMethod(m.method, raw(m))
+}
+
+// GobDecode implements gob.GobDecoder.
+func (m *ContainerUpdaterMethod) GobDecode(b []byte) error {
+	type raw ContainerUpdaterMethod
+	return decodeMethod(b, &m.method, (*raw)(m))
+}
+
+// GobEncode implements gob.GobEncoder.
+func (m IOMethod) GobEncode() ([]byte, error) {
+	type raw IOMethod
+	return encodeMethod(m.method, raw(m))
+}
+
+// GobDecode implements gob.GobDecoder.
+func (m *IOMethod) GobDecode(b []byte) error {
+	type raw IOMethod
+	return decodeMethod(b, &m.method, (*raw)(m))
+}
+
+// GobEncode implements gob.GobEncoder.
+func (m ContainerMethod) GobEncode() ([]byte, error) {
+	type raw ContainerMethod
+	return encodeMethod(m.method, raw(m))
+}
+
+// GobDecode implements gob.GobDecoder.
+func (m *ContainerMethod) GobDecode(b []byte) error {
+	type raw ContainerMethod
+	return decodeMethod(b, &m.method, (*raw)(m))
+}
+
+// legacyPackage is Package as it is decoded from legacy snapshots. Interface
+// methods are skipped, since their names and comments were never encoded.
+type legacyPackage struct {
+	Comment Comment
+
+	Enums        []Enumeration
+	TypeAliases  []TypeAlias
+	Structs      []Struct
+	ErrorStructs []ErrorStruct
+	Interfaces   []legacyInterface
+}
+
+type legacyInterface struct {
+	Comment Comment
+	Name    string
+	Embeds  []EmbeddedInterface
+}
+
+// DecodeLegacyGob decodes a gob snapshot generated before methods were gob
+// encoded with their names, which cannot be decoded into Packages directly.
+// The returned interfaces have no methods, so such snapshots should only be
+// compared against packages passed through StripMethods.
+func DecodeLegacyGob(r io.Reader) (Packages, error) {
+	var legacy map[string]legacyPackage
+	if err := gob.NewDecoder(r).Decode(&legacy); err != nil {
+		return nil, err
+	}
+
+	pkgs := make(Packages, len(legacy))
+
+	for path, lpkg := range legacy {
+		pkg := Package{
+			Comment:      lpkg.Comment,
+			Enums:        lpkg.Enums,
+			TypeAliases:  lpkg.TypeAliases,
+			Structs:      lpkg.Structs,
+			ErrorStructs: lpkg.ErrorStructs,
+			Interfaces:   make([]Interface, len(lpkg.Interfaces)),
+		}
+
+		for i, iface := range lpkg.Interfaces {
+			pkg.Interfaces[i] = Interface{
+				Comment: iface.Comment,
+				Name:    iface.Name,
+				Embeds:  iface.Embeds,
+			}
+		}
+
+		pkgs[path] = pkg
+	}
+
+	return pkgs, nil
+}
+
+// StripMethods returns a copy of pkgs with all interface methods removed.
+func StripMethods(pkgs Packages) Packages {
+	stripped := make(Packages, len(pkgs))
+
+	for path, pkg := range pkgs {
+		ifaces := make([]Interface, len(pkg.Interfaces))
+		for i, iface := range pkg.Interfaces {
+			iface.Methods = nil
+			ifaces[i] = iface
+		}
+
+		pkg.Interfaces = ifaces
+		stripped[path] = pkg
+	}
+
+	return stripped
+}
@@ -0,0 +1,260 @@
+package repository
+
+import (
+	"sort"
+	"strings"
+)
+
+// InterfaceRef refers to an interface inside Packages.
+type InterfaceRef struct {
+	Path string
+	Name string
+}
+
+// String returns the reference as a qualified identifier, similarly to
+// MakeQual.
+func (r InterfaceRef) String() string {
+	return makeQualPath(r.Path, r.Name)
+}
+
+// EdgeKind is the type of relationship between two interfaces.
+type EdgeKind uint8
+
+const (
+	// EmbedEdge is when the interface embeds another one.
+	EmbedEdge EdgeKind = iota
+	// AsserterEdge is when the interface has an asserter method returning the
+	// other interface.
+	AsserterEdge
+	// ContainerEdge is when the interface has a method taking in the other
+	// interface, which is a container.
+	ContainerEdge
+	// ValueEdge is when the interface has a method that returns, errors with
+	// or is given the other interface, either directly or through the fields
+	// of a struct.
+	ValueEdge
+)
+
+// String returns the name of the edge kind in lower case, such as "embed".
+func (k EdgeKind) String() string {
+	switch k {
+	case EmbedEdge:
+		return "embed"
+	case AsserterEdge:
+		return "asserter"
+	case ContainerEdge:
+		return "container"
+	case ValueEdge:
+		return "value"
+	default:
+		return "unknown"
+	}
+}
+
+// Edge is a relationship from one interface to another.
+type Edge struct {
+	From InterfaceRef
+	To   InterfaceRef
+	Kind EdgeKind
+	// Method is the name of the method that creates this edge. It is empty for
+	// EmbedEdge.
+	Method string
+}
+
+// Edges returns all edges between interfaces in the given packages in a stable
+// order. Types that cannot be resolved to an interface inside pkgs are
+// ignored.
+func Edges(pkgs Packages) []Edge {
+	var edges []Edge
+
+	for _, path := range sortedPaths(pkgs) {
+		for _, iface := range pkgs[path].Interfaces {
+			edges = append(edges, InterfaceEdges(pkgs, path, iface)...)
+		}
+	}
+
+	return edges
+}
+
+// InterfaceEdges returns the edges from the given interface inside the package
+// with the given path.
+func InterfaceEdges(pkgs Packages, path string, iface Interface) []Edge {
+	var edges []Edge
+	var from = InterfaceRef{path, iface.Name}
+
+	add := func(kind EdgeKind, method, typeName string) {
+		to, ok := resolveInterfaceRef(pkgs, path, typeName)
+		if !ok {
+			return
+		}
+		edges = append(edges, Edge{
+			From:   from,
+			To:     to,
+			Kind:   kind,
+			Method: method,
+		})
+	}
+
+	addValues := func(method string, types []NamedType, errorType string) {
+		for _, ref := range valueRefs(pkgs, path, types, errorType) {
+			kind := ValueEdge
+			if pkgs[ref.Path].Interface(ref.Name).IsContainer() {
+				kind = ContainerEdge
+			}
+			edges = append(edges, Edge{
+				From:   from,
+				To:     ref,
+				Kind:   kind,
+				Method: method,
+			})
+		}
+	}
+
+	for _, embed := range iface.Embeds {
+		add(EmbedEdge, "", embed.InterfaceName)
+	}
+
+	for _, method := range iface.Methods {
+		name := method.UnderlyingName()
+
+		switch method := method.(type) {
+		case GetterMethod:
+			addValues(name, append(method.Parameters, method.Returns...), method.ErrorType)
+		case SetterMethod:
+			addValues(name, method.Parameters, method.ErrorType)
+		case ContainerUpdaterMethod:
+			addValues(name, method.Parameters, method.ErrorType)
+		case IOMethod:
+			addValues(name, append(method.Parameters, method.ReturnValue), method.ErrorType)
+		case ContainerMethod:
+			add(ContainerEdge, name, method.ContainerType)
+		case AsserterMethod:
+			add(AsserterEdge, name, method.ChildType)
+		}
+	}
+
+	return edges
+}
+
+// valueRefs returns the interfaces referred to by the given types, either
+// directly or through the fields of structs. Each interface is only returned
+// once.
+func valueRefs(pkgs Packages, path string, types []NamedType, errorType string) []InterfaceRef {
+	var refs []InterfaceRef
+	var seen = map[InterfaceRef]bool{}
+
+	var walk func(path, typeName string)
+	walk = func(path, typeName string) {
+		ref, ok := resolveRef(path, typeName)
+		if !ok || seen[ref] {
+			return
+		}
+		seen[ref] = true
+
+		pkg, ok := pkgs[ref.Path]
+		if !ok {
+			return
+		}
+
+		if pkg.Interface(ref.Name) != nil {
+			refs = append(refs, ref)
+			return
+		}
+
+		if sstruct := pkg.Struct(ref.Name); sstruct != nil {
+			for _, field := range sstruct.Fields {
+				walk(ref.Path, field.Type)
+			}
+		}
+	}
+
+	for _, typ := range types {
+		walk(path, typ.Type)
+	}
+	walk(path, errorType)
+
+	return refs
+}
+
+// resolveInterfaceRef resolves the type name relative to the given path into
+// an interface. Slice and pointer types are unwrapped.
+func resolveInterfaceRef(pkgs Packages, path, typeName string) (InterfaceRef, bool) {
+	ref, ok := resolveRef(path, typeName)
+	if !ok {
+		return InterfaceRef{}, false
+	}
+
+	pkg, ok := pkgs[ref.Path]
+	if !ok || pkg.Interface(ref.Name) == nil {
+		return InterfaceRef{}, false
+	}
+
+	return ref, true
+}
+
+// resolveRef qualifies the type name relative to the given path. Slice and
+// pointer types are unwrapped. The returned reference may not exist.
+func resolveRef(path, typeName string) (InterfaceRef, bool) {
+	typeName = strings.TrimLeft(typeName, "[]*")
+	if typeName == "" {
+		return InterfaceRef{}, false
+	}
+
+	typePath, name := TypeQual(typeName)
+	if typePath != "" {
+		path = typePath
+	}
+
+	return InterfaceRef{path, name}, true
+}
+
+func sortedPaths(pkgs Packages) []string {
+	paths := make([]string, 0, len(pkgs))
+	for path := range pkgs {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// Reachable returns the set of interfaces that can be reached from root by
+// following edges of the given kinds. All kinds are followed if none are given.
+// The root is always reachable.
+func Reachable(pkgs Packages, root InterfaceRef, kinds ...EdgeKind) map[InterfaceRef]bool {
+	var follow = map[EdgeKind]bool{}
+	for _, kind := range kinds {
+		follow[kind] = true
+	}
+
+	return ReachableFunc(pkgs, root, func(edge Edge) bool {
+		return len(kinds) == 0 || follow[edge.Kind]
+	})
+}
+
+// ReachableFunc is like Reachable, except only the edges that follow returns
+// true for are followed.
+func ReachableFunc(pkgs Packages, root InterfaceRef, follow func(Edge) bool) map[InterfaceRef]bool {
+	var edges = map[InterfaceRef][]Edge{}
+	for _, edge := range Edges(pkgs) {
+		if follow(edge) {
+			edges[edge.From] = append(edges[edge.From], edge)
+		}
+	}
+
+	var reachable = map[InterfaceRef]bool{root: true}
+	var queue = []InterfaceRef{root}
+
+	for len(queue) > 0 {
+		ref := queue[0]
+		queue = queue[1:]
+
+		for _, edge := range edges[ref] {
+			if !reachable[edge.To] {
+				reachable[edge.To] = true
+				queue = append(queue, edge.To)
+			}
+		}
+	}
+
+	return reachable
+}
@@ -0,0 +1,242 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestEdges(t *testing.T) {
+	var textPath = MakePath("text")
+
+	var pkgs = Packages{
+		RootPath: {
+			Interfaces: []Interface{{
+				Name:   "Session",
+				Embeds: []EmbeddedInterface{{InterfaceName: "Lister"}},
+				Methods: []Method{
+					AsserterMethod{ChildType: "Commander"},
+				},
+			}, {
+				Name: "Lister",
+				Methods: []Method{
+					ContainerMethod{
+						method:        method{Name: "Servers"},
+						ContainerType: "ServersContainer",
+					},
+				},
+			}, {
+				Name: "ServersContainer",
+				Methods: []Method{
+					SetterMethod{
+						method:     method{Name: "SetServers"},
+						Parameters: []NamedType{{Type: "[]Server"}},
+					},
+				},
+			}, {
+				Name: "Server",
+				Methods: []Method{
+					GetterMethod{
+						method:  method{Name: "Name"},
+						Returns: []NamedType{{Type: MakeQual("text", "Rich")}},
+					},
+				},
+			}, {
+				Name: "Commander",
+			}},
+		},
+		textPath: {
+			Interfaces: []Interface{{Name: "Rich"}},
+		},
+	}
+
+	var expect = []Edge{
+		{
+			From: InterfaceRef{RootPath, "Session"},
+			To:   InterfaceRef{RootPath, "Lister"},
+			Kind: EmbedEdge,
+		},
+		{
+			From:   InterfaceRef{RootPath, "Session"},
+			To:     InterfaceRef{RootPath, "Commander"},
+			Kind:   AsserterEdge,
+			Method: "AsCommander",
+		},
+		{
+			From:   InterfaceRef{RootPath, "Lister"},
+			To:     InterfaceRef{RootPath, "ServersContainer"},
+			Kind:   ContainerEdge,
+			Method: "Servers",
+		},
+		{
+			From:   InterfaceRef{RootPath, "ServersContainer"},
+			To:     InterfaceRef{RootPath, "Server"},
+			Kind:   ValueEdge,
+			Method: "SetServers",
+		},
+		{
+			From:   InterfaceRef{RootPath, "Server"},
+			To:     InterfaceRef{textPath, "Rich"},
+			Kind:   ValueEdge,
+			Method: "Name",
+		},
+	}
+
+	if eq := deep.Equal(expect, Edges(pkgs)); eq != nil {
+		t.Fatal("Unexpected edges:", eq)
+	}
+}
+
+func TestValueEdges(t *testing.T) {
+	var pkgs = Packages{
+		RootPath: {
+			Structs: []Struct{{
+				Name: "Entry",
+				Fields: []StructField{
+					{NamedType: NamedType{Name: "Server", Type: "Server"}},
+					{NamedType: NamedType{Name: "Name", Type: "string"}},
+				},
+			}},
+			Interfaces: []Interface{{
+				Name: "Authenticator",
+				Methods: []Method{
+					IOMethod{
+						method:      method{Name: "Authenticate"},
+						ReturnValue: NamedType{Type: "Session"},
+						ErrorType:   "AuthenticateError",
+					},
+				},
+			}, {
+				Name: "Lister",
+				Methods: []Method{
+					GetterMethod{
+						method:  method{Name: "Entries"},
+						Returns: []NamedType{{Type: "[]Entry"}},
+					},
+				},
+			}, {
+				Name: "Session",
+			}, {
+				Name: "AuthenticateError",
+			}, {
+				Name: "Server",
+			}},
+		},
+	}
+
+	var expect = []Edge{
+		{
+			From:   InterfaceRef{RootPath, "Authenticator"},
+			To:     InterfaceRef{RootPath, "Session"},
+			Kind:   ValueEdge,
+			Method: "Authenticate",
+		},
+		{
+			From:   InterfaceRef{RootPath, "Authenticator"},
+			To:     InterfaceRef{RootPath, "AuthenticateError"},
+			Kind:   ValueEdge,
+			Method: "Authenticate",
+		},
+		{
+			From:   InterfaceRef{RootPath, "Lister"},
+			To:     InterfaceRef{RootPath, "Server"},
+			Kind:   ValueEdge,
+			Method: "Entries",
+		},
+	}
+
+	if eq := deep.Equal(expect, Edges(pkgs)); eq != nil {
+		t.Fatal("Unexpected edges:", eq)
+	}
+}
+
+func TestReachable(t *testing.T) {
+	var pkgs = Packages{
+		RootPath: {
+			Interfaces: []Interface{{
+				Name:   "Service",
+				Embeds: []EmbeddedInterface{{InterfaceName: "Namer"}},
+				Methods: []Method{
+					AsserterMethod{ChildType: "Configurator"},
+					GetterMethod{
+						method:  method{Name: "Authenticate"},
+						Returns: []NamedType{{Type: "[]Session"}},
+					},
+				},
+			}, {
+				Name: "Namer",
+			}, {
+				Name: "Configurator",
+			}, {
+				Name: "Session",
+				Methods: []Method{
+					ContainerMethod{
+						method:        method{Name: "Servers"},
+						ContainerType: "ServersContainer",
+					},
+				},
+			}, {
+				Name: "ServersContainer",
+			}, {
+				Name: "Orphan",
+			}},
+		},
+	}
+
+	var ref = func(name string) InterfaceRef { return InterfaceRef{RootPath, name} }
+
+	var tests = []struct {
+		name   string
+		kinds  []EdgeKind
+		expect map[InterfaceRef]bool
+	}{{
+		name:  "asserters",
+		kinds: []EdgeKind{EmbedEdge, AsserterEdge},
+		expect: map[InterfaceRef]bool{
+			ref("Service"):      true,
+			ref("Namer"):        true,
+			ref("Configurator"): true,
+		},
+	}, {
+		name: "all",
+		expect: map[InterfaceRef]bool{
+			ref("Service"):          true,
+			ref("Namer"):            true,
+			ref("Configurator"):     true,
+			ref("Session"):          true,
+			ref("ServersContainer"): true,
+		},
+	}, {
+		name:  "root only",
+		kinds: []EdgeKind{ContainerEdge},
+		expect: map[InterfaceRef]bool{
+			ref("Service"): true,
+		},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := Reachable(pkgs, ref("Service"), test.kinds...)
+			if eq := deep.Equal(test.expect, got); eq != nil {
+				t.Fatal("Unexpected reachable interfaces:", eq)
+			}
+		})
+	}
+
+	// Only the value edge of Authenticate is followed in addition to embeds
+	// and asserters.
+	got := ReachableFunc(pkgs, ref("Service"), func(edge Edge) bool {
+		return edge.Kind == EmbedEdge || edge.Kind == AsserterEdge ||
+			edge.Method == "Authenticate"
+	})
+
+	var expect = map[InterfaceRef]bool{
+		ref("Service"):      true,
+		ref("Namer"):        true,
+		ref("Configurator"): true,
+		ref("Session"):      true,
+	}
+	if eq := deep.Equal(expect, got); eq != nil {
+		t.Fatal("Unexpected reachable interfaces through Authenticate:", eq)
+	}
+}
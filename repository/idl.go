@@ -0,0 +1,300 @@
+package repository
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// IDLExt is the file extension of cchat IDL files.
+const IDLExt = ".cchat"
+
+// The IDL is a compact line-based text format describing a single Package. It
+// is written by PrintIDL and read by ParseIDL. Comments are written as "//"
+// lines directly above whatever they document, and blank lines detach them.
+// Below is an example of every declaration:
+//
+//    // Package cchat is an example.
+//    package github.com/diamondburned/cchat
+//
+//    bitwise enum Attribute {
+//        _
+//        Bold
+//    }
+//
+//    type ID = string
+//
+//    struct Rich {
+//        Content string
+//        stringer "%s" Content
+//    }
+//
+//    error struct ErrInvalidConfigAtField {
+//        Key string
+//        Err error
+//        error "Error at %s: %s" Key Err.Error()
+//    }
+//
+//    interface Session {
+//        embed Identifier
+//        getter ID() (ID)
+//        getter Completion(word string) (entries []CompletionEntry, ok bool) throws error
+//        setter SetName(name string)
+//        updater SetUnread(unread bool, mentioned bool)
+//        io Disconnect() () throws error disposer
+//        io Server(id ID) (Server) throws error
+//        container Servers(ServersContainer)
+//        container JoinServer(context.Context, MessagesContainer)
+//        asserter Commander
+//    }
+//
+// Enum values named "_" are placeholders. Types are written as-is and must not
+// contain spaces.
+
+// PrintIDL writes the package in the IDL format.
+func PrintIDL(w io.Writer, path string, pkg Package) error {
+	p := idlPrinter{w: bufio.NewWriter(w)}
+
+	p.comment(0, pkg.Comment)
+	p.printf(0, "package %s\n", path)
+
+	for _, enum := range pkg.Enums {
+		p.newline()
+		p.comment(0, enum.Comment)
+		if enum.Bitwise {
+			p.printf(0, "bitwise ")
+		}
+		p.printf(0, "enum %s {\n", enum.Name)
+		for _, value := range enum.Values {
+			p.comment(1, value.Comment)
+			if value.IsPlaceholder() {
+				p.printf(1, "_\n")
+			} else {
+				p.printf(1, "%s\n", value.Name)
+			}
+		}
+		p.printf(0, "}\n")
+	}
+
+	for _, alias := range pkg.TypeAliases {
+		p.newline()
+		p.comment(0, alias.Comment)
+		p.printf(0, "type %s = %s\n", alias.Name, alias.Type)
+	}
+
+	for _, sstruct := range pkg.Structs {
+		p.newline()
+		p.comment(0, sstruct.Comment)
+		p.printf(0, "struct %s {\n", sstruct.Name)
+		p.structBody(sstruct)
+		p.printf(0, "}\n")
+	}
+
+	for _, estruct := range pkg.ErrorStructs {
+		p.newline()
+		p.comment(0, estruct.Comment)
+		p.printf(0, "error struct %s {\n", estruct.Name)
+		p.structBody(estruct.Struct)
+		if !estruct.ErrorString.IsEmpty() {
+			p.printf(1, "error %s\n", tmplString(estruct.ErrorString))
+		}
+		p.printf(0, "}\n")
+	}
+
+	for _, iface := range pkg.Interfaces {
+		p.newline()
+		p.comment(0, iface.Comment)
+		p.printf(0, "interface %s {\n", iface.Name)
+		for _, embed := range iface.Embeds {
+			p.comment(1, embed.Comment)
+			p.printf(1, "embed %s\n", embed.InterfaceName)
+		}
+		for _, method := range iface.Methods {
+			p.comment(1, method.UnderlyingComment())
+			p.printf(1, "%s\n", idlMethod(method))
+		}
+		p.printf(0, "}\n")
+	}
+
+	if p.err != nil {
+		return p.err
+	}
+
+	return p.w.Flush()
+}
+
+type idlPrinter struct {
+	w   *bufio.Writer
+	err error
+}
+
+func (p *idlPrinter) printf(indent int, f string, v ...interface{}) {
+	if p.err != nil {
+		return
+	}
+	if indent > 0 {
+		_, p.err = p.w.WriteString(strings.Repeat("\t", indent))
+	}
+	if p.err == nil {
+		_, p.err = fmt.Fprintf(p.w, f, v...)
+	}
+}
+
+func (p *idlPrinter) newline() {
+	p.printf(0, "\n")
+}
+
+func (p *idlPrinter) comment(indent int, comment Comment) {
+	for _, line := range commentLines(comment) {
+		if line == "" {
+			p.printf(indent, "//\n")
+		} else {
+			p.printf(indent, "// %s\n", line)
+		}
+	}
+}
+
+func (p *idlPrinter) structBody(sstruct Struct) {
+	for _, field := range sstruct.Fields {
+		p.comment(1, field.Comment)
+		p.printf(1, "%s\n", namedType(field.NamedType))
+	}
+	if !sstruct.Stringer.IsEmpty() {
+		p.comment(1, sstruct.Stringer.Comment)
+		p.printf(1, "stringer %s\n", tmplString(sstruct.Stringer.TmplString))
+	}
+}
+
+func tmplString(tmpl TmplString) string {
+	var fields = append([]string{strconv.Quote(tmpl.Format)}, tmpl.Fields...)
+	return strings.Join(fields, " ")
+}
+
+func namedType(t NamedType) string {
+	if t.Name == "" {
+		return t.Type
+	}
+	return t.Name + " " + t.Type
+}
+
+func namedTypes(types []NamedType) string {
+	var strs = make([]string, len(types))
+	for i, t := range types {
+		strs[i] = namedType(t)
+	}
+	return "(" + strings.Join(strs, ", ") + ")"
+}
+
+func throws(errorType string) string {
+	if errorType == "" {
+		return ""
+	}
+	return " throws " + errorType
+}
+
+func idlMethod(method Method) string {
+	switch method := method.(type) {
+	case GetterMethod:
+		return fmt.Sprintf("getter %s%s %s%s",
+			method.Name, namedTypes(method.Parameters), namedTypes(method.Returns),
+			throws(method.ErrorType))
+
+	case SetterMethod:
+		return fmt.Sprintf("setter %s%s%s",
+			method.Name, namedTypes(method.Parameters), throws(method.ErrorType))
+
+	case ContainerUpdaterMethod:
+		return fmt.Sprintf("updater %s%s%s",
+			method.Name, namedTypes(method.Parameters), throws(method.ErrorType))
+
+	case IOMethod:
+		var returns []NamedType
+		if !method.ReturnValue.IsZero() {
+			returns = []NamedType{method.ReturnValue}
+		}
+
+		var disposer string
+		if method.Disposer {
+			disposer = " disposer"
+		}
+
+		return fmt.Sprintf("io %s%s %s%s%s",
+			method.Name, namedTypes(method.Parameters), namedTypes(returns),
+			throws(method.ErrorType), disposer)
+
+	case ContainerMethod:
+		var ctx string
+		if method.HasContext {
+			ctx = "context.Context, "
+		}
+		return fmt.Sprintf("container %s(%s%s)", method.Name, ctx, method.ContainerType)
+
+	case AsserterMethod:
+		return "asserter " + method.ChildType
+
+	default:
+		panic(fmt.Sprintf("unknown method type %T", method))
+	}
+}
+
+// commentLines returns the lines of the comment with the indentation used for
+// Go syntax removed. Empty lines are paragraph breaks.
+func commentLines(comment Comment) []string {
+	if comment.IsEmpty() {
+		return nil
+	}
+
+	txt := commentTrimSurrounding.ReplaceAllString(comment.Raw, "")
+	lines := strings.Split(txt, "\n")
+
+	// Trim the surrounding blank lines.
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	var indent = -1
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		lineIndent := len(line) - len(strings.TrimLeft(line, "\t"))
+		if indent == -1 || lineIndent < indent {
+			indent = lineIndent
+		}
+	}
+
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			lines[i] = ""
+		} else {
+			lines[i] = line[indent:]
+		}
+	}
+
+	return lines
+}
+
+// makeComment creates a comment from the given lines in the same form as the
+// comments written in Go literals.
+func makeComment(lines []string) Comment {
+	if len(lines) == 0 {
+		return Comment{}
+	}
+
+	var builder strings.Builder
+	builder.WriteByte('\n')
+	for _, line := range lines {
+		if line != "" {
+			builder.WriteByte('\t')
+			builder.WriteString(line)
+		}
+		builder.WriteByte('\n')
+	}
+
+	return Comment{Raw: builder.String()}
+}
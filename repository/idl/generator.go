@@ -0,0 +1,3 @@
+package idl
+
+//go:generate go run ../../cmd/internal/cchat-idl-gen
@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"path"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestIDLDir(t *testing.T) {
+	parsed, err := ParseIDLDir("idl")
+	if err != nil {
+		t.Fatal("Failed to parse IDL:", err)
+	}
+
+	// Comments are only compared after normalizing their indentation, which
+	// is lost in the IDL.
+	if eq := deep.Equal(idlNormalize(t, Main), idlNormalize(t, parsed)); eq != nil {
+		t.Fatal("Inequalities after parsing:", eq)
+	}
+}
+
+func TestIDLRoundTrip(t *testing.T) {
+	for pkgPath, pkg := range Main {
+		var printed bytes.Buffer
+		if err := PrintIDL(&printed, pkgPath, pkg); err != nil {
+			t.Fatal("Failed to print IDL:", err)
+		}
+
+		b, err := ioutil.ReadFile(filepath.Join("idl", path.Base(pkgPath)+IDLExt))
+		if err != nil {
+			t.Fatal("Failed to read IDL:", err)
+		}
+
+		if printed.String() != string(b) {
+			t.Errorf("IDL file for %s is outdated", pkgPath)
+		}
+
+		parsedPath, parsed, err := ParseIDL(bytes.NewReader(b))
+		if err != nil {
+			t.Fatal("Failed to parse IDL:", err)
+		}
+
+		if parsedPath != pkgPath {
+			t.Errorf("Unexpected package path %q", parsedPath)
+		}
+
+		var reprinted bytes.Buffer
+		if err := PrintIDL(&reprinted, parsedPath, parsed); err != nil {
+			t.Fatal("Failed to print IDL:", err)
+		}
+
+		if eq := deep.Equal(printed.String(), reprinted.String()); eq != nil {
+			t.Errorf("Inequalities after reprinting %s: %v", pkgPath, eq)
+		}
+	}
+}
+
+func TestIDLErrors(t *testing.T) {
+	var tests = []struct {
+		name  string
+		input string
+		err   string
+	}{{
+		name:  "no package",
+		input: "interface Foo {\n}\n",
+		err:   "1: expected package declaration",
+	}, {
+		name:  "unknown declaration",
+		input: "package foo\n\nfunc Foo()\n",
+		err:   `3: unexpected "func Foo()"`,
+	}, {
+		name:  "unknown method kind",
+		input: "package foo\ninterface Foo {\n\tdoer Do()\n}\n",
+		err:   `3: unknown method kind "doer"`,
+	}, {
+		name:  "io multiple returns",
+		input: "package foo\ninterface Foo {\n\tio Do() (a, b)\n}\n",
+		err:   "3: io method Do may only return one value",
+	}, {
+		name:  "unclosed",
+		input: "package foo\nenum Foo {\n\tBar\n",
+		err:   "3: unexpected EOF",
+	}, {
+		name:  "bad format",
+		input: "package foo\nstruct Foo {\n\tstringer %s Bar\n}\n",
+		err:   `3: invalid format string in "%s Bar"`,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, _, err := ParseIDL(strings.NewReader(test.input))
+			if err == nil {
+				t.Fatal("Unexpected nil error")
+			}
+			if err.Error() != test.err {
+				t.Fatalf("Unexpected error %q, expected %q", err, test.err)
+			}
+		})
+	}
+}
+
+// idlNormalize returns the JSON-decoded form of pkgs with all comments
+// normalized.
+func idlNormalize(t *testing.T, pkgs Packages) interface{} {
+	b, err := json.Marshal(pkgs)
+	if err != nil {
+		t.Fatal("Failed to JSON encode:", err)
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		t.Fatal("Failed to JSON decode:", err)
+	}
+
+	var walk func(v interface{})
+	walk = func(v interface{}) {
+		switch v := v.(type) {
+		case map[string]interface{}:
+			for k, child := range v {
+				if raw, ok := child.(string); ok && k == "Raw" {
+					v[k] = makeComment(commentLines(Comment{Raw: raw})).Raw
+					continue
+				}
+				walk(child)
+			}
+		case []interface{}:
+			for _, child := range v {
+				walk(child)
+			}
+		}
+	}
+
+	walk(v)
+	return v
+}
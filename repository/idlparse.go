@@ -0,0 +1,464 @@
+package repository
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ParseIDLDir parses all IDL files inside the given directory.
+func ParseIDLDir(dir string) (Packages, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var pkgs = Packages{}
+
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != IDLExt {
+			continue
+		}
+
+		path, pkg, err := parseIDLFile(filepath.Join(dir, file.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := pkgs[path]; ok {
+			return nil, fmt.Errorf("%s: package %s is already declared", file.Name(), path)
+		}
+
+		pkgs[path] = pkg
+	}
+
+	return pkgs, nil
+}
+
+func parseIDLFile(name string) (string, Package, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return "", Package{}, err
+	}
+	defer f.Close()
+
+	path, pkg, err := ParseIDL(f)
+	if err != nil {
+		return "", Package{}, fmt.Errorf("%s:%v", filepath.Base(name), err)
+	}
+
+	return path, pkg, nil
+}
+
+// ParseIDL parses a package written in the IDL format. Refer to PrintIDL.
+func ParseIDL(r io.Reader) (path string, pkg Package, err error) {
+	p := idlParser{scanner: bufio.NewScanner(r)}
+
+	defer func() {
+		if r := recover(); r != nil {
+			perr, ok := r.(idlError)
+			if !ok {
+				panic(r)
+			}
+			err = perr
+		}
+	}()
+
+	path, pkg = p.parse()
+	return
+}
+
+type idlError struct {
+	line int
+	msg  string
+}
+
+func (err idlError) Error() string {
+	return fmt.Sprintf("%d: %s", err.line, err.msg)
+}
+
+type idlParser struct {
+	scanner *bufio.Scanner
+	comment []string
+	line    int
+}
+
+func (p *idlParser) errorf(f string, v ...interface{}) {
+	panic(idlError{p.line, fmt.Sprintf(f, v...)})
+}
+
+// next returns the next line that is not a comment or blank. Comment lines are
+// collected for takeComment. False is returned on EOF.
+func (p *idlParser) next() (string, bool) {
+	for p.scanner.Scan() {
+		p.line++
+
+		line := strings.TrimSpace(p.scanner.Text())
+		switch {
+		case line == "":
+			p.comment = nil
+		case strings.HasPrefix(line, "//"):
+			line = strings.TrimPrefix(line, "//")
+			line = strings.TrimPrefix(line, " ")
+			p.comment = append(p.comment, line)
+		default:
+			return line, true
+		}
+	}
+
+	if err := p.scanner.Err(); err != nil {
+		p.errorf("failed to read: %v", err)
+	}
+
+	return "", false
+}
+
+// mustNext is next, except EOF is an error.
+func (p *idlParser) mustNext() string {
+	line, ok := p.next()
+	if !ok {
+		p.errorf("unexpected EOF")
+	}
+	return line
+}
+
+func (p *idlParser) takeComment() Comment {
+	comment := makeComment(p.comment)
+	p.comment = nil
+	return comment
+}
+
+func (p *idlParser) parse() (string, Package) {
+	var pkg Package
+
+	line, ok := p.next()
+	if !ok || !strings.HasPrefix(line, "package ") {
+		p.errorf("expected package declaration")
+	}
+
+	pkg.Comment = p.takeComment()
+	path := strings.TrimSpace(strings.TrimPrefix(line, "package "))
+
+	for {
+		line, ok := p.next()
+		if !ok {
+			break
+		}
+
+		comment := p.takeComment()
+		words := strings.Fields(line)
+
+		switch {
+		case matchDecl(words, "enum"):
+			pkg.Enums = append(pkg.Enums, p.parseEnum(comment, words[1], false))
+
+		case matchDecl(words, "bitwise", "enum"):
+			pkg.Enums = append(pkg.Enums, p.parseEnum(comment, words[2], true))
+
+		case len(words) == 4 && words[0] == "type" && words[2] == "=":
+			pkg.TypeAliases = append(pkg.TypeAliases, TypeAlias{
+				Comment:   comment,
+				NamedType: NamedType{Name: words[1], Type: words[3]},
+			})
+
+		case matchDecl(words, "struct"):
+			sstruct := Struct{Comment: comment, Name: words[1]}
+			p.parseStruct(&sstruct, nil)
+			pkg.Structs = append(pkg.Structs, sstruct)
+
+		case matchDecl(words, "error", "struct"):
+			estruct := ErrorStruct{Struct: Struct{Comment: comment, Name: words[2]}}
+			p.parseStruct(&estruct.Struct, &estruct.ErrorString)
+			pkg.ErrorStructs = append(pkg.ErrorStructs, estruct)
+
+		case matchDecl(words, "interface"):
+			pkg.Interfaces = append(pkg.Interfaces, p.parseInterface(comment, words[1]))
+
+		default:
+			p.errorf("unexpected %q", line)
+		}
+	}
+
+	return path, pkg
+}
+
+// matchDecl returns true if words is the given keywords followed by a name and
+// an opening brace.
+func matchDecl(words []string, keywords ...string) bool {
+	if len(words) != len(keywords)+2 || words[len(words)-1] != "{" {
+		return false
+	}
+	for i, keyword := range keywords {
+		if words[i] != keyword {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *idlParser) parseEnum(comment Comment, name string, bitwise bool) Enumeration {
+	enum := Enumeration{
+		Comment: comment,
+		Name:    name,
+		Bitwise: bitwise,
+	}
+
+	for {
+		line := p.mustNext()
+		if line == "}" {
+			return enum
+		}
+
+		value := EnumValue{Comment: p.takeComment()}
+		if line != "_" {
+			value.Name = line
+		}
+
+		enum.Values = append(enum.Values, value)
+	}
+}
+
+func (p *idlParser) parseStruct(sstruct *Struct, errorString *TmplString) {
+	for {
+		line := p.mustNext()
+		if line == "}" {
+			return
+		}
+
+		comment := p.takeComment()
+		words := strings.SplitN(line, " ", 2)
+
+		switch {
+		case words[0] == "stringer" && len(words) == 2:
+			sstruct.Stringer = Stringer{
+				Comment:    comment,
+				TmplString: p.parseTmplString(words[1]),
+			}
+
+		case words[0] == "error" && len(words) == 2 && errorString != nil:
+			*errorString = p.parseTmplString(words[1])
+
+		default:
+			sstruct.Fields = append(sstruct.Fields, StructField{
+				Comment:   comment,
+				NamedType: p.parseNamedType(line),
+			})
+		}
+	}
+}
+
+func (p *idlParser) parseTmplString(str string) TmplString {
+	var quoted string
+
+	if strings.HasPrefix(str, `"`) {
+	scan:
+		for i := 1; i < len(str); i++ {
+			switch str[i] {
+			case '\\':
+				i++
+			case '"':
+				quoted = str[:i+1]
+				break scan
+			}
+		}
+	}
+
+	format, err := strconv.Unquote(quoted)
+	if err != nil {
+		p.errorf("invalid format string in %q", str)
+	}
+
+	return TmplString{
+		Format: format,
+		Fields: strings.Fields(str[len(quoted):]),
+	}
+}
+
+func (p *idlParser) parseInterface(comment Comment, name string) Interface {
+	iface := Interface{
+		Comment: comment,
+		Name:    name,
+	}
+
+	for {
+		line := p.mustNext()
+		if line == "}" {
+			return iface
+		}
+
+		comment := p.takeComment()
+		words := strings.SplitN(line, " ", 2)
+		if len(words) != 2 {
+			p.errorf("unexpected %q", line)
+		}
+
+		if words[0] == "embed" {
+			iface.Embeds = append(iface.Embeds, EmbeddedInterface{
+				Comment:       comment,
+				InterfaceName: words[1],
+			})
+			continue
+		}
+
+		iface.Methods = append(iface.Methods, p.parseMethod(comment, words[0], words[1]))
+	}
+}
+
+func (p *idlParser) parseMethod(comment Comment, kind, sig string) Method {
+	if kind == "asserter" {
+		return AsserterMethod{ChildType: sig}
+	}
+
+	paren := strings.IndexByte(sig, '(')
+	if paren < 1 {
+		p.errorf("missing parameters in %q", sig)
+	}
+
+	var m = method{Comment: comment, Name: sig[:paren]}
+
+	params, rest := p.parseTypes(sig[paren:])
+
+	var returns []NamedType
+	if kind == "getter" || kind == "io" {
+		returns, rest = p.parseTypes(rest)
+	}
+
+	// Parse the trailing words.
+	var errorType string
+	var disposer bool
+
+	words := strings.Fields(rest)
+	for i := 0; i < len(words); i++ {
+		switch {
+		case words[i] == "throws" && i+1 < len(words):
+			errorType = words[i+1]
+			i++
+		case words[i] == "disposer" && kind == "io":
+			disposer = true
+		default:
+			p.errorf("unexpected %q", words[i])
+		}
+	}
+
+	switch kind {
+	case "getter":
+		return GetterMethod{
+			method:     m,
+			Parameters: params,
+			Returns:    returns,
+			ErrorType:  errorType,
+		}
+
+	case "setter":
+		return SetterMethod{
+			method:     m,
+			Parameters: params,
+			ErrorType:  errorType,
+		}
+
+	case "updater":
+		return ContainerUpdaterMethod{
+			method:     m,
+			Parameters: params,
+			ErrorType:  errorType,
+		}
+
+	case "io":
+		var ret NamedType
+		switch len(returns) {
+		case 0:
+		case 1:
+			ret = returns[0]
+		default:
+			p.errorf("io method %s may only return one value", m.Name)
+		}
+
+		return IOMethod{
+			method:      m,
+			Parameters:  params,
+			ReturnValue: ret,
+			ErrorType:   errorType,
+			Disposer:    disposer,
+		}
+
+	case "container":
+		if errorType != "" {
+			p.errorf("container method %s cannot throw", m.Name)
+		}
+
+		var hasContext bool
+		if len(params) == 2 && params[0] == (NamedType{Type: "context.Context"}) {
+			hasContext = true
+			params = params[1:]
+		}
+		if len(params) != 1 || params[0].Name != "" {
+			p.errorf("container method %s must only take in a container", m.Name)
+		}
+
+		return ContainerMethod{
+			method:        m,
+			HasContext:    hasContext,
+			ContainerType: params[0].Type,
+		}
+
+	default:
+		p.errorf("unknown method kind %q", kind)
+		return nil
+	}
+}
+
+// parseTypes parses a parenthesized list of named types at the start of str.
+// The rest of the string is returned.
+func (p *idlParser) parseTypes(str string) ([]NamedType, string) {
+	str = strings.TrimSpace(str)
+	if !strings.HasPrefix(str, "(") {
+		p.errorf("expected ( in %q", str)
+	}
+
+	var types []NamedType
+	var depth int
+	var start = 1
+
+	for i := 1; i < len(str); i++ {
+		switch str[i] {
+		case '(':
+			depth++
+		case ')':
+			if depth > 0 {
+				depth--
+				continue
+			}
+			if s := strings.TrimSpace(str[start:i]); s != "" {
+				types = append(types, p.parseNamedType(s))
+			}
+			return types, str[i+1:]
+		case ',':
+			if depth == 0 {
+				types = append(types, p.parseNamedType(str[start:i]))
+				start = i + 1
+			}
+		}
+	}
+
+	p.errorf("missing ) in %q", str)
+	return nil, ""
+}
+
+func (p *idlParser) parseNamedType(str string) NamedType {
+	words := strings.Fields(str)
+
+	switch len(words) {
+	case 1:
+		return NamedType{Type: words[0]}
+	case 2:
+		return NamedType{Name: words[0], Type: words[1]}
+	default:
+		p.errorf("invalid type %q", str)
+		return NamedType{}
+	}
+}
@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// MarshalJSON encodes the comment into JSON with its text as returned by Text,
+// so that it doesn't contain the indentation of the Go source that it was
+// written in.
+func (c Comment) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct{ Raw string }{c.Text()})
+}
+
+// MarshalJSON encodes the struct field into JSON. It is needed to keep the
+// fields of NamedType, since the embedded Comment's MarshalJSON would be
+// promoted otherwise.
+func (f StructField) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Raw string
+		NamedType
+	}{
+		f.Text(),
+		f.NamedType,
+	})
+}
+
+// MarshalJSON encodes the stringer into JSON. It is needed for the same reason
+// as StructField's.
+func (s Stringer) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Raw string
+		TmplString
+	}{
+		s.Text(),
+		s.TmplString,
+	})
+}
+
+// MarshalJSON encodes the interface into JSON. Methods are encoded as a tagged
+// union: each method object has a "Kind" field containing the name of the
+// method type, such as "IOMethod", followed by the fields of that type.
+func (i Interface) MarshalJSON() ([]byte, error) {
+	type raw Interface
+
+	var methods []jsonMethod
+	if i.Methods != nil {
+		methods = make([]jsonMethod, len(i.Methods))
+	}
+	for j, method := range i.Methods {
+		methods[j] = jsonMethod{method}
+	}
+
+	return json.Marshal(struct {
+		raw
+		Methods []jsonMethod
+	}{
+		raw(i),
+		methods,
+	})
+}
+
+// UnmarshalJSON decodes the JSON encoded by MarshalJSON.
+func (i *Interface) UnmarshalJSON(b []byte) error {
+	type raw Interface
+
+	var v struct {
+		*raw
+		Methods []jsonMethod
+	}
+	v.raw = (*raw)(i)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	i.Methods = nil
+	if v.Methods != nil {
+		i.Methods = make([]Method, len(v.Methods))
+	}
+	for j, method := range v.Methods {
+		i.Methods[j] = method.Method
+	}
+
+	return nil
+}
+
+// jsonMethod wraps a Method to encode it as a tagged union.
+type jsonMethod struct {
+	Method
+}
+
+func (m jsonMethod) MarshalJSON() ([]byte, error) {
+	b, err := json.Marshal(m.Method)
+	if err != nil {
+		return nil, err
+	}
+
+	kind, err := json.Marshal(MethodKind(m.Method))
+	if err != nil {
+		return nil, err
+	}
+
+	// Prepend the Kind field into the object.
+	var buf bytes.Buffer
+	buf.Grow(len(b) + len(kind) + 10)
+	buf.WriteString(`{"Kind":`)
+	buf.Write(kind)
+	if len(b) > 2 {
+		buf.WriteByte(',')
+	}
+	buf.Write(b[1:])
+
+	return buf.Bytes(), nil
+}
+
+func (m *jsonMethod) UnmarshalJSON(b []byte) error {
+	var tag struct{ Kind string }
+	if err := json.Unmarshal(b, &tag); err != nil {
+		return err
+	}
+
+	switch tag.Kind {
+	case "GetterMethod":
+		var method GetterMethod
+		err := json.Unmarshal(b, &method)
+		m.Method = method
+		return err
+	case "SetterMethod":
+		var method SetterMethod
+		err := json.Unmarshal(b, &method)
+		m.Method = method
+		return err
+	case "ContainerUpdaterMethod":
+		var method ContainerUpdaterMethod
+		err := json.Unmarshal(b, &method)
+		m.Method = method
+		return err
+	case "IOMethod":
+		var method IOMethod
+		err := json.Unmarshal(b, &method)
+		m.Method = method
+		return err
+	case "ContainerMethod":
+		var method ContainerMethod
+		err := json.Unmarshal(b, &method)
+		m.Method = method
+		return err
+	case "AsserterMethod":
+		var method AsserterMethod
+		err := json.Unmarshal(b, &method)
+		m.Method = method
+		return err
+	default:
+		return fmt.Errorf("unknown method kind %q", tag.Kind)
+	}
+}
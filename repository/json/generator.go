@@ -0,0 +1,3 @@
+package json
+
+//go:generate go run ../../cmd/internal/cchat-json-gen
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestJSON(t *testing.T) {
+	b, err := json.Marshal(Main)
+	if err != nil {
+		t.Fatal("Failed to JSON encode:", err)
+	}
+
+	t.Log("Marshaled; total bytes:", len(b))
+
+	var unmarshaled Packages
+
+	if err := json.Unmarshal(b, &unmarshaled); err != nil {
+		t.Fatal("Failed to JSON decode:", err)
+	}
+
+	for _, change := range Compare(Main, unmarshaled) {
+		t.Error("Change after unmarshaling:", change)
+	}
+
+	// Comments are unindented when encoded, so only the second encoding can be
+	// compared byte by byte.
+	again, err := json.Marshal(unmarshaled)
+	if err != nil {
+		t.Fatal("Failed to JSON encode again:", err)
+	}
+
+	if !bytes.Equal(b, again) {
+		t.Fatal("Inequalities after unmarshaling and marshaling again.")
+	}
+}
+
+func TestJSONComment(t *testing.T) {
+	var field = StructField{
+		Comment:   Comment{"\n\t\t\tName is the name.\n\n\t\t\t    code\n\t\t"},
+		NamedType: NamedType{Name: "Name", Type: "string"},
+	}
+
+	b, err := json.Marshal(field)
+	if err != nil {
+		t.Fatal("Failed to JSON encode:", err)
+	}
+
+	const expect = `{"Raw":"Name is the name.\n\n    code","Name":"Name","Type":"string"}`
+	if string(b) != expect {
+		t.Fatal("Unexpected JSON:", string(b))
+	}
+
+	var unmarshaled StructField
+
+	if err := json.Unmarshal(b, &unmarshaled); err != nil {
+		t.Fatal("Failed to JSON decode:", err)
+	}
+
+	if got, want := unmarshaled.GoString(1), field.GoString(1); got != want {
+		t.Fatalf("Unexpected comment after unmarshaling:\n%s\nexpected:\n%s", got, want)
+	}
+}
+
+func TestJSONMethodKind(t *testing.T) {
+	var iface = Interface{
+		Name: "Sender",
+		Methods: []Method{
+			IOMethod{
+				method:    method{Name: "Send"},
+				ErrorType: "error",
+			},
+			AsserterMethod{ChildType: "Completer"},
+		},
+	}
+
+	b, err := json.Marshal(iface)
+	if err != nil {
+		t.Fatal("Failed to JSON encode:", err)
+	}
+
+	for _, part := range []string{
+		`{"Kind":"IOMethod","Comment":{"Raw":""},"Name":"Send"`,
+		`{"Kind":"AsserterMethod","ChildType":"Completer"}`,
+	} {
+		if !strings.Contains(string(b), part) {
+			t.Errorf("Missing %s in %s", part, b)
+		}
+	}
+
+	if err := json.Unmarshal([]byte(`{"Methods":[{"Kind":"Foo"}]}`), &iface); err == nil {
+		t.Error("Unexpected nil error on unknown kind.")
+	}
+}
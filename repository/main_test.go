@@ -23,6 +23,10 @@ func TestGob(t *testing.T) {
 		t.Fatal("Failed to gob decode:", err)
 	}
 
+	// Method names and comments are in unexported fields.
+	deep.CompareUnexportedFields = true
+	defer func() { deep.CompareUnexportedFields = false }()
+
 	if eq := deep.Equal(Main, unmarshaled); eq != nil {
 		t.Fatal("Inequalities after unmarshaling:", eq)
 	}
@@ -6,6 +6,10 @@ import (
 	"strings"
 )
 
+// RootPath is the root Go module path. This path is prefixed in every package
+// path.
+const RootPath = "github.com/diamondburned/cchat"
+
 // MakePath returns RootPath joined with relPath.
 func MakePath(relPath string) string {
 	return path.Join(RootPath, relPath)
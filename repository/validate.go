@@ -0,0 +1,256 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ValidateRoots is the list of interfaces in RootPath that are allowed to not
+// be referenced anywhere else, since they are the entry points of the API.
+var ValidateRoots = []string{"Service"}
+
+// ValidateNoError is the list of IOMethods in RootPath, as "Interface.Method",
+// that are allowed to have no ErrorType, since adding one would break the API.
+var ValidateNoError = []string{
+	"MemberDynamicSection.LoadMore",
+	"MemberDynamicSection.LoadLess",
+}
+
+// Validate checks the given packages for common mistakes that would either
+// generate code that doesn't compile or compile silently into a broken API. It
+// returns all errors found in a stable order, or nil if there are none.
+//
+// The following rules are checked:
+//
+//    - AsserterMethod.ChildType and ContainerMethod.ContainerType must resolve
+//      to an interface.
+//    - ContainerMethod.ContainerType must be a container interface, that is
+//      its name must end in "Container".
+//    - EmbeddedInterface.InterfaceName must resolve to an interface.
+//    - IOMethod must have an ErrorType, unless it is in ValidateNoError.
+//    - Enumeration values must fit in the type returned by GoType.
+//    - ErrorStruct names must start with "Err".
+//    - Interfaces must be referenced by something, such as by being asserted
+//      or embedded, unless they are in ValidateRoots.
+//
+func Validate(pkgs Packages) []error {
+	v := validator{
+		pkgs:       pkgs,
+		referenced: map[string]bool{},
+	}
+
+	paths := sortedPaths(pkgs)
+
+	for _, path := range paths {
+		v.validatePackage(path, pkgs[path])
+	}
+
+	for _, path := range paths {
+		v.validateReferenced(path, pkgs[path])
+	}
+
+	return v.errors
+}
+
+type validator struct {
+	pkgs       Packages
+	errors     []error
+	referenced map[string]bool // qualified name -> referenced
+}
+
+func (v *validator) errorf(path, format string, args ...interface{}) {
+	err := fmt.Errorf("%s: %s", trimRootOrPath(path), fmt.Sprintf(format, args...))
+	v.errors = append(v.errors, err)
+}
+
+// trimRootOrPath returns the path relative to RootPath, or the path itself if
+// it is RootPath or outside of it.
+func trimRootOrPath(path string) string {
+	if rel := TrimRoot(path); rel != "" {
+		return rel
+	}
+	return path
+}
+
+func (v *validator) validatePackage(path string, pkg Package) {
+	for _, enum := range pkg.Enums {
+		if enum.GoType() == "" {
+			v.errorf(path, "enum %s: too many values (%d)", enum.Name, len(enum.Values))
+		}
+	}
+
+	for _, estruct := range pkg.ErrorStructs {
+		if !strings.HasPrefix(estruct.Name, "Err") {
+			v.errorf(path, "error struct %s: name does not start with Err", estruct.Name)
+		}
+		v.referenceFields(path, estruct.Fields)
+	}
+
+	for _, sstruct := range pkg.Structs {
+		v.referenceFields(path, sstruct.Fields)
+	}
+
+	for _, alias := range pkg.TypeAliases {
+		v.reference(path, alias.Type)
+	}
+
+	for _, iface := range pkg.Interfaces {
+		v.validateInterface(path, iface)
+	}
+}
+
+func (v *validator) validateInterface(path string, iface Interface) {
+	for _, embed := range iface.Embeds {
+		if v.resolveInterface(path, embed.InterfaceName) == nil {
+			v.errorf(path, "interface %s: embedded interface %s not found",
+				iface.Name, embed.InterfaceName)
+		}
+	}
+
+	for _, method := range iface.Methods {
+		var name = method.UnderlyingName()
+
+		switch method := method.(type) {
+		case GetterMethod:
+			v.referenceTypes(path, method.Parameters)
+			v.referenceTypes(path, method.Returns)
+			v.reference(path, method.ErrorType)
+
+		case SetterMethod:
+			v.referenceTypes(path, method.Parameters)
+			v.reference(path, method.ErrorType)
+
+		case ContainerUpdaterMethod:
+			v.referenceTypes(path, method.Parameters)
+			v.reference(path, method.ErrorType)
+
+		case IOMethod:
+			v.referenceTypes(path, method.Parameters)
+			v.reference(path, method.ReturnValue.Type)
+			v.reference(path, method.ErrorType)
+
+			if !method.ReturnError() && !(path == RootPath && isNoError(iface.Name, name)) {
+				v.errorf(path, "interface %s: IOMethod %s has no ErrorType", iface.Name, name)
+			}
+
+		case ContainerMethod:
+			container := v.resolveInterface(path, method.ContainerType)
+			switch {
+			case container == nil:
+				v.errorf(path, "interface %s: method %s: container %s not found",
+					iface.Name, name, method.ContainerType)
+			case !container.IsContainer():
+				v.errorf(path, "interface %s: method %s: %s is not a container interface",
+					iface.Name, name, method.ContainerType)
+			}
+
+		case AsserterMethod:
+			if v.resolveInterface(path, method.ChildType) == nil {
+				v.errorf(path, "interface %s: asserter %s: interface %s not found",
+					iface.Name, name, method.ChildType)
+			}
+		}
+	}
+}
+
+func (v *validator) validateReferenced(path string, pkg Package) {
+	for _, iface := range pkg.Interfaces {
+		if v.referenced[makeQualPath(path, iface.Name)] {
+			continue
+		}
+		if path == RootPath && isRoot(iface.Name) {
+			continue
+		}
+
+		v.errorf(path, "interface %s: never asserted, embedded or referenced", iface.Name)
+	}
+}
+
+func isRoot(name string) bool {
+	for _, root := range ValidateRoots {
+		if root == name {
+			return true
+		}
+	}
+	return false
+}
+
+func isNoError(iface, method string) bool {
+	for _, noError := range ValidateNoError {
+		if noError == iface+"."+method {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveInterface finds the interface with the given type name, which may be
+// qualified with another package's path. The interface is also marked as
+// referenced.
+func (v *validator) resolveInterface(path, typeName string) *Interface {
+	path, name := v.qual(path, typeName)
+
+	pkg, ok := v.pkgs[path]
+	if !ok {
+		return nil
+	}
+
+	iface := pkg.Interface(name)
+	if iface != nil {
+		v.referenced[makeQualPath(path, name)] = true
+	}
+
+	return iface
+}
+
+func (v *validator) referenceFields(path string, fields []StructField) {
+	for _, field := range fields {
+		v.reference(path, field.Type)
+	}
+}
+
+func (v *validator) referenceTypes(path string, types []NamedType) {
+	for _, typ := range types {
+		v.reference(path, typ.Type)
+	}
+}
+
+// reference marks the type as referenced. Slice, pointer and map types are
+// unwrapped.
+func (v *validator) reference(path, typeName string) {
+	if typeName == "" {
+		return
+	}
+
+	typeName = strings.TrimLeft(typeName, "[]*")
+
+	if strings.HasPrefix(typeName, "map[") {
+		end := strings.Index(typeName, "]")
+		if end == -1 {
+			return
+		}
+
+		v.reference(path, typeName[len("map["):end])
+		v.reference(path, typeName[end+1:])
+		return
+	}
+
+	path, name := v.qual(path, typeName)
+	v.referenced[makeQualPath(path, name)] = true
+}
+
+// qual splits the type name using TypeQual and returns the current path if the
+// type is not qualified.
+func (v *validator) qual(path, typeName string) (string, string) {
+	typePath, name := TypeQual(typeName)
+	if typePath == "" {
+		return path, name
+	}
+	return typePath, name
+}
+
+// makeQualPath returns a qualified identifier similarly to MakeQual, except the
+// given path is used as-is.
+func makeQualPath(path, name string) string {
+	return fmt.Sprintf("(%s).%s", path, name)
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestValidateMain(t *testing.T) {
+	for _, err := range Validate(Main) {
+		t.Error(err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	var pkgs = Packages{
+		RootPath: {
+			ErrorStructs: []ErrorStruct{{
+				Struct: Struct{Name: "BadError"},
+			}},
+			Interfaces: []Interface{{
+				Name: "Service",
+				Methods: []Method{
+					AsserterMethod{ChildType: "Session"},
+					AsserterMethod{ChildType: "MemberDynamicSection"},
+					AsserterMethod{ChildType: "Missing"},
+					ContainerMethod{
+						method:        method{Name: "Watch"},
+						ContainerType: "Session",
+					},
+				},
+			}, {
+				Name:   "Session",
+				Embeds: []EmbeddedInterface{{InterfaceName: "Identifier"}},
+				Methods: []Method{
+					IOMethod{method: method{Name: "Load"}},
+				},
+			}, {
+				Name: "MemberDynamicSection",
+				Methods: []Method{
+					IOMethod{method: method{Name: "LoadMore"}},
+				},
+			}, {
+				Name: "Orphan",
+			}},
+		},
+	}
+
+	var expect = []string{
+		"github.com/diamondburned/cchat: error struct BadError: name does not start with Err",
+		"github.com/diamondburned/cchat: interface Service: asserter AsMissing: interface Missing not found",
+		"github.com/diamondburned/cchat: interface Service: method Watch: Session is not a container interface",
+		"github.com/diamondburned/cchat: interface Session: embedded interface Identifier not found",
+		"github.com/diamondburned/cchat: interface Session: IOMethod Load has no ErrorType",
+		"github.com/diamondburned/cchat: interface Orphan: never asserted, embedded or referenced",
+	}
+
+	var errs = Validate(pkgs)
+	var got = make([]string, len(errs))
+	for i, err := range errs {
+		got[i] = err.Error()
+	}
+
+	if eq := deep.Equal(expect, got); eq != nil {
+		t.Fatal("Unexpected errors:", eq)
+	}
+}
@@ -15,12 +15,24 @@
 // load should do this. A package can call RegisterService() multiple times.
 //
 // For examples on using RegisterSource(), check the plugins package.
+//
+// Tracing
+//
+// All method calls on services returned by Get() can be traced by calling
+// SetTracer(). Refer to the trace package inside utils.
+//
+// Timeouts
+//
+// Deadlines can be enforced on blocking methods of services returned by Get()
+// by calling SetTimeouts(). Refer to the timeout package inside utils.
 package services
 
 import (
 	"sync"
 
 	"github.com/diamondburned/cchat"
+	"github.com/diamondburned/cchat/utils/timeout"
+	"github.com/diamondburned/cchat/utils/trace"
 )
 
 var services []cchat.Service
@@ -40,6 +52,23 @@ func RegisterSource(source func() []error) {
 	sources = append(sources, source)
 }
 
+var tracer trace.Tracer
+
+// SetTracer sets the tracer that services returned by Get() are wrapped with.
+// Tracing is disabled if t is nil. It must be called before Get().
+func SetTracer(t trace.Tracer) {
+	tracer = t
+}
+
+var timeouts *timeout.Timeouts
+
+// SetTimeouts sets the deadlines that services returned by Get() enforce on
+// their blocking methods. No deadlines are enforced if t is nil. It must be
+// called before Get().
+func SetTimeouts(t *timeout.Timeouts) {
+	timeouts = t
+}
+
 // Get returns all services. It will also fetch the plugins from all sources.
 // Future calls will not fetch the plugins again.
 func Get() ([]cchat.Service, []error) {
@@ -50,6 +79,21 @@ func Get() ([]cchat.Service, []error) {
 		}
 	})
 
+	if tracer != nil || timeouts != nil {
+		wrapped := make([]cchat.Service, len(services))
+		for i, service := range services {
+			// Tracing goes outside, so abandoned calls are traced too.
+			if timeouts != nil {
+				service = timeout.Service(service, timeouts)
+			}
+			if tracer != nil {
+				service = trace.Service(service, tracer)
+			}
+			wrapped[i] = service
+		}
+		return wrapped, sourceErrs
+	}
+
 	// why are we here, just to suffer
 	return services, sourceErrs
 }
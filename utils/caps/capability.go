@@ -0,0 +1,76 @@
+// Package caps provides runtime introspection of the optional interfaces that
+// a backend implements. It walks every asserter method recursively and
+// reports which of them return non-nil interfaces.
+//
+// Usage
+//
+// Frontends can inspect a server once to decide what to show:
+//
+//    cap := caps.Server(server)
+//    if cap.Implements("cchat.Sender") {
+//        // Show the message composer.
+//    }
+//
+// Backends can print the report in their tests to verify what they expose:
+//
+//    t.Log(caps.Session(session))
+//
+// Note that asserters are called once each and the results are discarded, so
+// backends must not do IO in them, as required by cchat.
+package caps
+
+import "strings"
+
+// Capability describes whether an interface is implemented and, if so, the
+// capabilities of all interfaces that it can be asserted into.
+type Capability struct {
+	// Name is the Go type name of the interface, such as "cchat.Server".
+	Name        string
+	Implemented bool
+	// Asserted contains the capabilities of the interfaces returned by each
+	// asserter method in declaration order. It is nil if the interface is not
+	// implemented.
+	Asserted []Capability
+}
+
+// Implements returns true if c or any of its asserted capabilities has the
+// given name and is implemented.
+func (c Capability) Implements(name string) bool {
+	if !c.Implemented {
+		return false
+	}
+	if c.Name == name {
+		return true
+	}
+	for _, asserted := range c.Asserted {
+		if asserted.Implements(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// String formats the capability as an indented tree, with each line prefixed
+// with "+" if the interface is implemented or "-" if it is not.
+func (c Capability) String() string {
+	var builder strings.Builder
+	c.writeTo(&builder, 0)
+	return strings.TrimSuffix(builder.String(), "\n")
+}
+
+func (c Capability) writeTo(builder *strings.Builder, depth int) {
+	builder.WriteString(strings.Repeat("  ", depth))
+
+	if c.Implemented {
+		builder.WriteString("+ ")
+	} else {
+		builder.WriteString("- ")
+	}
+
+	builder.WriteString(c.Name)
+	builder.WriteByte('\n')
+
+	for _, asserted := range c.Asserted {
+		asserted.writeTo(builder, depth+1)
+	}
+}
@@ -0,0 +1,643 @@
+// Code generated by ./cmd/internal. DO NOT EDIT.
+
+package caps
+
+import (
+	"github.com/diamondburned/cchat"
+	"github.com/diamondburned/cchat/text"
+)
+
+// Noncer returns the capability of v.
+func Noncer(v cchat.Noncer) Capability {
+	return Capability{
+		Implemented: v != nil,
+		Name:        "cchat.Noncer",
+	}
+}
+
+// Service returns the capability of v and its asserted interfaces.
+func Service(v cchat.Service) Capability {
+	c := Capability{
+		Implemented: v != nil,
+		Name:        "cchat.Service",
+	}
+	if !c.Implemented {
+		return c
+	}
+
+	c.Asserted = []Capability{
+		Configurator(v.AsConfigurator()),
+		SessionRestorer(v.AsSessionRestorer()),
+	}
+	return c
+}
+
+// SessionRestorer returns the capability of v.
+func SessionRestorer(v cchat.SessionRestorer) Capability {
+	return Capability{
+		Implemented: v != nil,
+		Name:        "cchat.SessionRestorer",
+	}
+}
+
+// Configurator returns the capability of v.
+func Configurator(v cchat.Configurator) Capability {
+	return Capability{
+		Implemented: v != nil,
+		Name:        "cchat.Configurator",
+	}
+}
+
+// Session returns the capability of v and its asserted interfaces.
+func Session(v cchat.Session) Capability {
+	c := Capability{
+		Implemented: v != nil,
+		Name:        "cchat.Session",
+	}
+	if !c.Implemented {
+		return c
+	}
+
+	c.Asserted = []Capability{
+		Commander(v.AsCommander()),
+		SessionSaver(v.AsSessionSaver()),
+		PresenceSetter(v.AsPresenceSetter()),
+		Profiler(v.AsProfiler()),
+		DirectMessager(v.AsDirectMessager()),
+		Emojier(v.AsEmojier()),
+		ConnectionStater(v.AsConnectionStater()),
+	}
+	return c
+}
+
+// ConnectionStater returns the capability of v.
+func ConnectionStater(v cchat.ConnectionStater) Capability {
+	return Capability{
+		Implemented: v != nil,
+		Name:        "cchat.ConnectionStater",
+	}
+}
+
+// DirectMessager returns the capability of v.
+func DirectMessager(v cchat.DirectMessager) Capability {
+	return Capability{
+		Implemented: v != nil,
+		Name:        "cchat.DirectMessager",
+	}
+}
+
+// PresenceSetter returns the capability of v.
+func PresenceSetter(v cchat.PresenceSetter) Capability {
+	return Capability{
+		Implemented: v != nil,
+		Name:        "cchat.PresenceSetter",
+	}
+}
+
+// SessionSaver returns the capability of v.
+func SessionSaver(v cchat.SessionSaver) Capability {
+	return Capability{
+		Implemented: v != nil,
+		Name:        "cchat.SessionSaver",
+	}
+}
+
+// Commander returns the capability of v and its asserted interfaces.
+func Commander(v cchat.Commander) Capability {
+	c := Capability{
+		Implemented: v != nil,
+		Name:        "cchat.Commander",
+	}
+	if !c.Implemented {
+		return c
+	}
+
+	c.Asserted = []Capability{
+		Completer(v.AsCompleter()),
+	}
+	return c
+}
+
+// Server returns the capability of v and its asserted interfaces.
+func Server(v cchat.Server) Capability {
+	c := Capability{
+		Implemented: v != nil,
+		Name:        "cchat.Server",
+	}
+	if !c.Implemented {
+		return c
+	}
+
+	c.Asserted = []Capability{
+		Lister(v.AsLister()),
+		Messenger(v.AsMessenger()),
+		Commander(v.AsCommander()),
+		Configurator(v.AsConfigurator()),
+		NotificationSettings(v.AsNotificationSettings()),
+	}
+	return c
+}
+
+// NotificationSettings returns the capability of v.
+func NotificationSettings(v cchat.NotificationSettings) Capability {
+	return Capability{
+		Implemented: v != nil,
+		Name:        "cchat.NotificationSettings",
+	}
+}
+
+// Lister returns the capability of v.
+func Lister(v cchat.Lister) Capability {
+	return Capability{
+		Implemented: v != nil,
+		Name:        "cchat.Lister",
+	}
+}
+
+// Messenger returns the capability of v and its asserted interfaces.
+func Messenger(v cchat.Messenger) Capability {
+	c := Capability{
+		Implemented: v != nil,
+		Name:        "cchat.Messenger",
+	}
+	if !c.Implemented {
+		return c
+	}
+
+	c.Asserted = []Capability{
+		Sender(v.AsSender()),
+		Editor(v.AsEditor()),
+		Deleter(v.AsDeleter()),
+		Actioner(v.AsActioner()),
+		Nicknamer(v.AsNicknamer()),
+		Backlogger(v.AsBacklogger()),
+		MemberLister(v.AsMemberLister()),
+		ReadIndicator(v.AsReadIndicator()),
+		UnreadIndicator(v.AsUnreadIndicator()),
+		TypingIndicator(v.AsTypingIndicator()),
+		Profiler(v.AsProfiler()),
+		Pinner(v.AsPinner()),
+		Emojier(v.AsEmojier()),
+		DraftSyncer(v.AsDraftSyncer()),
+	}
+	return c
+}
+
+// DraftSyncer returns the capability of v.
+func DraftSyncer(v cchat.DraftSyncer) Capability {
+	return Capability{
+		Implemented: v != nil,
+		Name:        "cchat.DraftSyncer",
+	}
+}
+
+// Emojier returns the capability of v.
+func Emojier(v cchat.Emojier) Capability {
+	return Capability{
+		Implemented: v != nil,
+		Name:        "cchat.Emojier",
+	}
+}
+
+// Sender returns the capability of v and its asserted interfaces.
+func Sender(v cchat.Sender) Capability {
+	c := Capability{
+		Implemented: v != nil,
+		Name:        "cchat.Sender",
+	}
+	if !c.Implemented {
+		return c
+	}
+
+	c.Asserted = []Capability{
+		Completer(v.AsCompleter()),
+		SendStateIndicator(v.AsSendStateIndicator()),
+	}
+	return c
+}
+
+// SendStateIndicator returns the capability of v.
+func SendStateIndicator(v cchat.SendStateIndicator) Capability {
+	return Capability{
+		Implemented: v != nil,
+		Name:        "cchat.SendStateIndicator",
+	}
+}
+
+// Editor returns the capability of v.
+func Editor(v cchat.Editor) Capability {
+	return Capability{
+		Implemented: v != nil,
+		Name:        "cchat.Editor",
+	}
+}
+
+// Deleter returns the capability of v.
+func Deleter(v cchat.Deleter) Capability {
+	return Capability{
+		Implemented: v != nil,
+		Name:        "cchat.Deleter",
+	}
+}
+
+// Pinner returns the capability of v.
+func Pinner(v cchat.Pinner) Capability {
+	return Capability{
+		Implemented: v != nil,
+		Name:        "cchat.Pinner",
+	}
+}
+
+// Actioner returns the capability of v and its asserted interfaces.
+func Actioner(v cchat.Actioner) Capability {
+	c := Capability{
+		Implemented: v != nil,
+		Name:        "cchat.Actioner",
+	}
+	if !c.Implemented {
+		return c
+	}
+
+	c.Asserted = []Capability{
+		ActionDescriber(v.AsActionDescriber()),
+	}
+	return c
+}
+
+// ActionDescriber returns the capability of v.
+func ActionDescriber(v cchat.ActionDescriber) Capability {
+	return Capability{
+		Implemented: v != nil,
+		Name:        "cchat.ActionDescriber",
+	}
+}
+
+// Nicknamer returns the capability of v.
+func Nicknamer(v cchat.Nicknamer) Capability {
+	return Capability{
+		Implemented: v != nil,
+		Name:        "cchat.Nicknamer",
+	}
+}
+
+// Backlogger returns the capability of v.
+func Backlogger(v cchat.Backlogger) Capability {
+	return Capability{
+		Implemented: v != nil,
+		Name:        "cchat.Backlogger",
+	}
+}
+
+// MemberLister returns the capability of v.
+func MemberLister(v cchat.MemberLister) Capability {
+	return Capability{
+		Implemented: v != nil,
+		Name:        "cchat.MemberLister",
+	}
+}
+
+// ReadIndicator returns the capability of v.
+func ReadIndicator(v cchat.ReadIndicator) Capability {
+	return Capability{
+		Implemented: v != nil,
+		Name:        "cchat.ReadIndicator",
+	}
+}
+
+// UnreadIndicator returns the capability of v.
+func UnreadIndicator(v cchat.UnreadIndicator) Capability {
+	return Capability{
+		Implemented: v != nil,
+		Name:        "cchat.UnreadIndicator",
+	}
+}
+
+// TypingIndicator returns the capability of v.
+func TypingIndicator(v cchat.TypingIndicator) Capability {
+	return Capability{
+		Implemented: v != nil,
+		Name:        "cchat.TypingIndicator",
+	}
+}
+
+// Profiler returns the capability of v.
+func Profiler(v cchat.Profiler) Capability {
+	return Capability{
+		Implemented: v != nil,
+		Name:        "cchat.Profiler",
+	}
+}
+
+// Completer returns the capability of v.
+func Completer(v cchat.Completer) Capability {
+	return Capability{
+		Implemented: v != nil,
+		Name:        "cchat.Completer",
+	}
+}
+
+// ServerUpdate returns the capability of v and its asserted interfaces.
+func ServerUpdate(v cchat.ServerUpdate) Capability {
+	c := Capability{
+		Implemented: v != nil,
+		Name:        "cchat.ServerUpdate",
+	}
+	if !c.Implemented {
+		return c
+	}
+
+	c.Asserted = []Capability{
+		Lister(v.AsLister()),
+		Messenger(v.AsMessenger()),
+		Commander(v.AsCommander()),
+		Configurator(v.AsConfigurator()),
+		NotificationSettings(v.AsNotificationSettings()),
+	}
+	return c
+}
+
+// MessageCreate returns the capability of v and its asserted interfaces.
+func MessageCreate(v cchat.MessageCreate) Capability {
+	c := Capability{
+		Implemented: v != nil,
+		Name:        "cchat.MessageCreate",
+	}
+	if !c.Implemented {
+		return c
+	}
+
+	c.Asserted = []Capability{
+		MassMentioner(v.AsMassMentioner()),
+	}
+	return c
+}
+
+// MassMentioner returns the capability of v.
+func MassMentioner(v cchat.MassMentioner) Capability {
+	return Capability{
+		Implemented: v != nil,
+		Name:        "cchat.MassMentioner",
+	}
+}
+
+// UnreadContainer returns the capability of v and its asserted interfaces.
+func UnreadContainer(v cchat.UnreadContainer) Capability {
+	c := Capability{
+		Implemented: v != nil,
+		Name:        "cchat.UnreadContainer",
+	}
+	if !c.Implemented {
+		return c
+	}
+
+	c.Asserted = []Capability{
+		UnreadCountContainer(v.AsUnreadCountContainer()),
+	}
+	return c
+}
+
+// UnreadCountContainer returns the capability of v.
+func UnreadCountContainer(v cchat.UnreadCountContainer) Capability {
+	return Capability{
+		Implemented: v != nil,
+		Name:        "cchat.UnreadCountContainer",
+	}
+}
+
+// ListMember returns the capability of v and its asserted interfaces.
+func ListMember(v cchat.ListMember) Capability {
+	c := Capability{
+		Implemented: v != nil,
+		Name:        "cchat.ListMember",
+	}
+	if !c.Implemented {
+		return c
+	}
+
+	c.Asserted = []Capability{
+		DirectMessager(v.AsDirectMessager()),
+	}
+	return c
+}
+
+// MemberSection returns the capability of v and its asserted interfaces.
+func MemberSection(v cchat.MemberSection) Capability {
+	c := Capability{
+		Implemented: v != nil,
+		Name:        "cchat.MemberSection",
+	}
+	if !c.Implemented {
+		return c
+	}
+
+	c.Asserted = []Capability{
+		MemberDynamicSection(v.AsMemberDynamicSection()),
+	}
+	return c
+}
+
+// MemberDynamicSection returns the capability of v.
+func MemberDynamicSection(v cchat.MemberDynamicSection) Capability {
+	return Capability{
+		Implemented: v != nil,
+		Name:        "cchat.MemberDynamicSection",
+	}
+}
+
+// SendableMessage returns the capability of v and its asserted interfaces.
+func SendableMessage(v cchat.SendableMessage) Capability {
+	c := Capability{
+		Implemented: v != nil,
+		Name:        "cchat.SendableMessage",
+	}
+	if !c.Implemented {
+		return c
+	}
+
+	c.Asserted = []Capability{
+		Noncer(v.AsNoncer()),
+		Replier(v.AsReplier()),
+		Attacher(v.AsAttacher()),
+	}
+	return c
+}
+
+// Replier returns the capability of v.
+func Replier(v cchat.Replier) Capability {
+	return Capability{
+		Implemented: v != nil,
+		Name:        "cchat.Replier",
+	}
+}
+
+// Attacher returns the capability of v and its asserted interfaces.
+func Attacher(v cchat.Attacher) Capability {
+	c := Capability{
+		Implemented: v != nil,
+		Name:        "cchat.Attacher",
+	}
+	if !c.Implemented {
+		return c
+	}
+
+	c.Asserted = []Capability{
+		UploadProgressContainer(v.AsUploadProgressContainer()),
+	}
+	return c
+}
+
+// UploadProgressContainer returns the capability of v.
+func UploadProgressContainer(v cchat.UploadProgressContainer) Capability {
+	return Capability{
+		Implemented: v != nil,
+		Name:        "cchat.UploadProgressContainer",
+	}
+}
+
+// TextSegment returns the capability of v and its asserted interfaces.
+func TextSegment(v text.Segment) Capability {
+	c := Capability{
+		Implemented: v != nil,
+		Name:        "text.Segment",
+	}
+	if !c.Implemented {
+		return c
+	}
+
+	c.Asserted = []Capability{
+		TextColorer(v.AsColorer()),
+		TextLinker(v.AsLinker()),
+		TextImager(v.AsImager()),
+		TextAvatarer(v.AsAvatarer()),
+		TextMentioner(v.AsMentioner()),
+		TextAttributor(v.AsAttributor()),
+		TextCodeblocker(v.AsCodeblocker()),
+		TextQuoteblocker(v.AsQuoteblocker()),
+		TextMessageReferencer(v.AsMessageReferencer()),
+	}
+	return c
+}
+
+// TextMessageReferencer returns the capability of v.
+func TextMessageReferencer(v text.MessageReferencer) Capability {
+	return Capability{
+		Implemented: v != nil,
+		Name:        "text.MessageReferencer",
+	}
+}
+
+// TextLinker returns the capability of v.
+func TextLinker(v text.Linker) Capability {
+	return Capability{
+		Implemented: v != nil,
+		Name:        "text.Linker",
+	}
+}
+
+// TextImager returns the capability of v.
+func TextImager(v text.Imager) Capability {
+	return Capability{
+		Implemented: v != nil,
+		Name:        "text.Imager",
+	}
+}
+
+// TextAvatarer returns the capability of v.
+func TextAvatarer(v text.Avatarer) Capability {
+	return Capability{
+		Implemented: v != nil,
+		Name:        "text.Avatarer",
+	}
+}
+
+// TextColorer returns the capability of v.
+func TextColorer(v text.Colorer) Capability {
+	return Capability{
+		Implemented: v != nil,
+		Name:        "text.Colorer",
+	}
+}
+
+// TextMentioner returns the capability of v.
+func TextMentioner(v text.Mentioner) Capability {
+	return Capability{
+		Implemented: v != nil,
+		Name:        "text.Mentioner",
+	}
+}
+
+// TextAttributor returns the capability of v.
+func TextAttributor(v text.Attributor) Capability {
+	return Capability{
+		Implemented: v != nil,
+		Name:        "text.Attributor",
+	}
+}
+
+// TextCodeblocker returns the capability of v.
+func TextCodeblocker(v text.Codeblocker) Capability {
+	return Capability{
+		Implemented: v != nil,
+		Name:        "text.Codeblocker",
+	}
+}
+
+// TextQuoteblocker returns the capability of v.
+func TextQuoteblocker(v text.Quoteblocker) Capability {
+	return Capability{
+		Implemented: v != nil,
+		Name:        "text.Quoteblocker",
+	}
+}
+
+// Inspect returns the capabilities of every interface with asserters that
+// v implements. Nil is returned if v implements none of them.
+func Inspect(v interface{}) []Capability {
+	var caps []Capability
+	if v, ok := v.(cchat.Service); ok {
+		caps = append(caps, Service(v))
+	}
+	if v, ok := v.(cchat.Session); ok {
+		caps = append(caps, Session(v))
+	}
+	if v, ok := v.(cchat.Commander); ok {
+		caps = append(caps, Commander(v))
+	}
+	if v, ok := v.(cchat.Server); ok {
+		caps = append(caps, Server(v))
+	}
+	if v, ok := v.(cchat.Messenger); ok {
+		caps = append(caps, Messenger(v))
+	}
+	if v, ok := v.(cchat.Sender); ok {
+		caps = append(caps, Sender(v))
+	}
+	if v, ok := v.(cchat.Actioner); ok {
+		caps = append(caps, Actioner(v))
+	}
+	if v, ok := v.(cchat.ServerUpdate); ok {
+		caps = append(caps, ServerUpdate(v))
+	}
+	if v, ok := v.(cchat.MessageCreate); ok {
+		caps = append(caps, MessageCreate(v))
+	}
+	if v, ok := v.(cchat.UnreadContainer); ok {
+		caps = append(caps, UnreadContainer(v))
+	}
+	if v, ok := v.(cchat.ListMember); ok {
+		caps = append(caps, ListMember(v))
+	}
+	if v, ok := v.(cchat.MemberSection); ok {
+		caps = append(caps, MemberSection(v))
+	}
+	if v, ok := v.(cchat.SendableMessage); ok {
+		caps = append(caps, SendableMessage(v))
+	}
+	if v, ok := v.(cchat.Attacher); ok {
+		caps = append(caps, Attacher(v))
+	}
+	if v, ok := v.(text.Segment); ok {
+		caps = append(caps, TextSegment(v))
+	}
+	return caps
+}
@@ -0,0 +1,118 @@
+package caps
+
+import (
+	"context"
+	"testing"
+
+	"github.com/diamondburned/cchat"
+	"github.com/diamondburned/cchat/utils/empty"
+)
+
+type server struct {
+	empty.Server
+	messenger *messenger
+}
+
+func (s *server) ID() cchat.ID { return "1" }
+
+func (s *server) Name(context.Context, cchat.LabelContainer) (func(), error) {
+	return func() {}, nil
+}
+
+// AsMessenger returns s.messenger as a nil interface if it is nil.
+func (s *server) AsMessenger() cchat.Messenger {
+	if s.messenger == nil {
+		return nil
+	}
+	return s.messenger
+}
+
+type messenger struct {
+	empty.Messenger
+	sender
+}
+
+func (m *messenger) JoinServer(context.Context, cchat.MessagesContainer) (func(), error) {
+	return func() {}, nil
+}
+
+func (m *messenger) AsSender() cchat.Sender { return &m.sender }
+
+type sender struct {
+	empty.Sender
+}
+
+func (sender) CanAttach() bool                                   { return false }
+func (sender) Send(context.Context, cchat.SendableMessage) error { return nil }
+
+func TestServer(t *testing.T) {
+	s := &server{messenger: &messenger{}}
+	c := Server(s)
+
+	if !c.Implemented {
+		t.Fatal("Server is not implemented")
+	}
+
+	for name, expect := range map[string]bool{
+		"cchat.Server":             true,
+		"cchat.Messenger":          true,
+		"cchat.Sender":             true,
+		"cchat.Completer":          false,
+		"cchat.Lister":             false,
+		"cchat.SendStateIndicator": false,
+	} {
+		if c.Implements(name) != expect {
+			t.Errorf("Implements(%q) != %v", name, expect)
+		}
+	}
+
+	const expect = `+ cchat.Server
+  - cchat.Lister
+  + cchat.Messenger
+    + cchat.Sender
+      - cchat.Completer
+      - cchat.SendStateIndicator
+    - cchat.Editor
+    - cchat.Deleter
+    - cchat.Actioner
+    - cchat.Nicknamer
+    - cchat.Backlogger
+    - cchat.MemberLister
+    - cchat.ReadIndicator
+    - cchat.UnreadIndicator
+    - cchat.TypingIndicator
+    - cchat.Profiler
+    - cchat.Pinner
+    - cchat.Emojier
+    - cchat.DraftSyncer
+  - cchat.Commander
+  - cchat.Configurator
+  - cchat.NotificationSettings`
+
+	if str := c.String(); str != expect {
+		t.Fatalf("Unexpected report:\n%s", str)
+	}
+}
+
+func TestInspect(t *testing.T) {
+	var s cchat.Server = &server{}
+
+	caps := Inspect(s)
+	if len(caps) != 1 || caps[0].Name != "cchat.Server" {
+		t.Fatalf("Unexpected capabilities: %v", caps)
+	}
+
+	if caps[0].Implements("cchat.Messenger") {
+		t.Fatal("Unexpected Messenger for a server without one")
+	}
+
+	// The messenger also implements Sender through embedding.
+	caps = Inspect(&messenger{})
+	if len(caps) != 2 || caps[0].Name != "cchat.Messenger" || caps[1].Name != "cchat.Sender" {
+		t.Fatalf("Unexpected capabilities: %v", caps)
+	}
+
+	if caps := Inspect(nil); caps != nil {
+		t.Fatalf("Unexpected capabilities for nil: %v", caps)
+	}
+}
@@ -0,0 +1,341 @@
+// Package contract provides generated wrappers that check at runtime whether
+// backends follow the contracts of cchat's containers, which is useful for
+// catching backend bugs before they crash frontends.
+//
+// Usage
+//
+// Wrapping a service wraps everything returned from it as well, including
+// asserted interfaces. Containers given to wrapped methods are also wrapped, so
+// every call that the backend makes on them is checked:
+//
+//    logger := log.New(os.Stderr, "cchat: ", log.LstdFlags)
+//    service = contract.Service(service, contract.New(contract.NewLogger(logger)))
+//
+// The following contracts are checked:
+//
+//    - Containers are not called after the stop callback returns, after their
+//      container method returns an error, or after the blocking method they
+//      were given to returns if it has no stop callback.
+//    - SetServers is called before UpdateServer.
+//    - UpdateMessage and DeleteMessage are only called with IDs of created
+//      messages that have not been deleted.
+//    - Created messages have a non-nil Author.
+//    - Segments of text.Rich values given to containers are within the
+//      content.
+//
+// Container calls that violate a contract are reported to the Handler and are
+// not passed to the frontend.
+//
+// Message IDs are tracked per container and forgotten once the container is
+// stopped. Messages created through Backlog's container are also added to the
+// containers of every JoinServer call on the same server that has not been
+// stopped yet, since they may be updated through those. Messengers are matched
+// by the ID of the server they were asserted from, so asserting the same server
+// multiple times is fine.
+package contract
+
+import (
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/diamondburned/cchat"
+	"github.com/diamondburned/cchat/text"
+)
+
+const (
+	joinServer = "cchat.Messenger.JoinServer"
+	backlog    = "cchat.Backlogger.Backlog"
+)
+
+// Violation is a container call that violates a contract.
+type Violation struct {
+	// Container is the blocking method that the container was given to, such
+	// as "cchat.Messenger.JoinServer". It is empty if the container was
+	// wrapped directly.
+	Container string
+	// Method is the container method that was called, such as
+	// "cchat.MessagesContainer.UpdateMessage".
+	Method string
+	Reason string
+}
+
+// Error formats the violation into a single line.
+func (v Violation) Error() string {
+	if v.Container == "" {
+		return fmt.Sprintf("%s: %s", v.Method, v.Reason)
+	}
+	return fmt.Sprintf("%s from %s: %s", v.Method, v.Container, v.Reason)
+}
+
+// Handler is called on every violation. It may be called from multiple
+// goroutines at once.
+type Handler interface {
+	Handle(Violation)
+}
+
+// HandlerFunc is a function that implements Handler.
+type HandlerFunc func(Violation)
+
+// Handle calls f.
+func (f HandlerFunc) Handle(v Violation) { f(v) }
+
+// NewLogger returns a Handler that prints every violation into the given
+// logger.
+func NewLogger(l *log.Logger) Handler {
+	return HandlerFunc(func(v Violation) { l.Println("contract violation:", v.Error()) })
+}
+
+// Checker checks calls on containers and reports violations to its handler.
+type Checker struct {
+	handler  Handler
+	messages *idSet
+	scope    *scope
+	joins    *joins
+	// server is the ID of the server that the Messenger was asserted from.
+	server cchat.ID
+}
+
+// New creates a new Checker that reports to the given handler.
+func New(h Handler) *Checker {
+	return &Checker{
+		handler:  h,
+		messages: newIDSet(),
+		scope:    &scope{},
+		joins:    &joins{servers: map[cchat.ID]map[*idSet]struct{}{}},
+	}
+}
+
+// idSet is a set of IDs that is safe to use concurrently.
+type idSet struct {
+	mu  sync.Mutex
+	ids map[cchat.ID]struct{}
+}
+
+func newIDSet() *idSet {
+	return &idSet{ids: map[cchat.ID]struct{}{}}
+}
+
+func (s *idSet) add(id cchat.ID) {
+	s.mu.Lock()
+	s.ids[id] = struct{}{}
+	s.mu.Unlock()
+}
+
+// clear removes all IDs from the set.
+func (s *idSet) clear() {
+	s.mu.Lock()
+	s.ids = map[cchat.ID]struct{}{}
+	s.mu.Unlock()
+}
+
+// joins contains the message IDs of the JoinServer calls that have not been
+// stopped yet, keyed by server ID. It is shared by all Checkers created from
+// the same New call.
+type joins struct {
+	mu      sync.Mutex
+	servers map[cchat.ID]map[*idSet]struct{}
+}
+
+func (j *joins) add(server cchat.ID, messages *idSet) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	sets, ok := j.servers[server]
+	if !ok {
+		sets = map[*idSet]struct{}{}
+		j.servers[server] = sets
+	}
+	sets[messages] = struct{}{}
+}
+
+func (j *joins) remove(server cchat.ID, messages *idSet) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	delete(j.servers[server], messages)
+	if len(j.servers[server]) == 0 {
+		delete(j.servers, server)
+	}
+}
+
+// create adds the message ID to all JoinServer calls of the server.
+func (j *joins) create(server, id cchat.ID) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	for messages := range j.servers[server] {
+		messages.add(id)
+	}
+}
+
+// scope is the state of the containers given to a blocking method call.
+type scope struct {
+	method string
+
+	mu      sync.Mutex
+	stopped bool
+	servers bool
+}
+
+// messenger creates the Checker for a Messenger asserted from the server with
+// the given ID.
+func (c *Checker) messenger(server cchat.ID) *Checker {
+	return &Checker{
+		handler:  c.handler,
+		messages: c.messages,
+		scope:    c.scope,
+		joins:    c.joins,
+		server:   server,
+	}
+}
+
+// enter creates the Checker for the containers given to the blocking method,
+// which get their own set of message IDs. The IDs of JoinServer calls are
+// tracked until they are stopped, so that Backlog can add to them.
+func (c *Checker) enter(method string) *Checker {
+	entered := &Checker{
+		handler:  c.handler,
+		messages: newIDSet(),
+		scope:    &scope{method: method},
+		joins:    c.joins,
+		server:   c.server,
+	}
+
+	if method == joinServer {
+		c.joins.add(c.server, entered.messages)
+	}
+
+	return entered
+}
+
+// stop marks the containers of c as stopped and forgets their message IDs.
+func (c *Checker) stop() {
+	c.scope.mu.Lock()
+	c.scope.stopped = true
+	c.scope.mu.Unlock()
+
+	if c.scope.method == joinServer {
+		c.joins.remove(c.server, c.messages)
+	}
+	c.messages.clear()
+}
+
+// stopFunc wraps the stop callback returned by a container method to stop c
+// after the callback returns. The containers are stopped right away if err is
+// not nil.
+func (c *Checker) stopFunc(stop func(), err error) func() {
+	if err != nil {
+		c.stop()
+		return stop
+	}
+
+	if stop == nil {
+		return nil
+	}
+
+	return func() {
+		stop()
+		c.stop()
+	}
+}
+
+func (c *Checker) violate(method, reason string, v ...interface{}) bool {
+	c.handler.Handle(Violation{
+		Container: c.scope.method,
+		Method:    method,
+		Reason:    fmt.Sprintf(reason, v...),
+	})
+	return false
+}
+
+// call checks that the containers of c can still be called.
+func (c *Checker) call(method string) bool {
+	c.scope.mu.Lock()
+	stopped := c.scope.stopped
+	c.scope.mu.Unlock()
+
+	if stopped {
+		return c.violate(method, "called after being stopped")
+	}
+	return true
+}
+
+// checkRich checks that all segments of the given text are within its
+// content.
+func (c *Checker) checkRich(method string, rich text.Rich) bool {
+	for i, segment := range rich.Segments {
+		if segment == nil {
+			return c.violate(method, "segment %d is nil", i)
+		}
+
+		start, end := segment.Bounds()
+		if start < 0 || start > end || end > len(rich.Content) {
+			return c.violate(method,
+				"segment %d has bounds [%d, %d) outside content of length %d",
+				i, start, end, len(rich.Content))
+		}
+	}
+
+	return true
+}
+
+func (c *Checker) setServers(method string, _ []cchat.Server) bool {
+	c.scope.mu.Lock()
+	c.scope.servers = true
+	c.scope.mu.Unlock()
+
+	return true
+}
+
+func (c *Checker) updateServer(method string, _ cchat.ServerUpdate) bool {
+	c.scope.mu.Lock()
+	servers := c.scope.servers
+	c.scope.mu.Unlock()
+
+	if !servers {
+		return c.violate(method, "called before SetServers")
+	}
+	return true
+}
+
+func (c *Checker) createMessage(method string, msg cchat.MessageCreate) bool {
+	if msg.Author() == nil {
+		return c.violate(method, "message %q has a nil Author", msg.ID())
+	}
+
+	if !c.checkRich(method, msg.Content()) {
+		return false
+	}
+
+	c.messages.add(msg.ID())
+	if c.scope.method == backlog {
+		c.joins.create(c.server, msg.ID())
+	}
+
+	return true
+}
+
+func (c *Checker) updateMessage(method string, msg cchat.MessageUpdate) bool {
+	c.messages.mu.Lock()
+	_, ok := c.messages.ids[msg.ID()]
+	c.messages.mu.Unlock()
+
+	if !ok {
+		return c.violate(method, "message %q was never created", msg.ID())
+	}
+
+	return c.checkRich(method, msg.Content())
+}
+
+func (c *Checker) deleteMessage(method string, msg cchat.MessageDelete) bool {
+	c.messages.mu.Lock()
+	_, ok := c.messages.ids[msg.ID()]
+	delete(c.messages.ids, msg.ID())
+	c.messages.mu.Unlock()
+
+	if !ok {
+		return c.violate(method, "message %q was never created", msg.ID())
+	}
+	return true
+}
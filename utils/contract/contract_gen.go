@@ -0,0 +1,2063 @@
+// Code generated by ./cmd/internal. DO NOT EDIT.
+
+package contract
+
+import (
+	"context"
+	"github.com/diamondburned/cchat"
+	"github.com/diamondburned/cchat/text"
+	"time"
+)
+
+type identifier struct {
+	v cchat.Identifier
+	t *Checker
+}
+
+// Identifier wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func Identifier(v cchat.Identifier, t *Checker) cchat.Identifier {
+	if v == nil {
+		return nil
+	}
+	return identifier{v, t}
+}
+
+func (w identifier) ID() cchat.ID {
+	r0 := w.v.ID()
+	return r0
+}
+
+type namer struct {
+	v cchat.Namer
+	t *Checker
+}
+
+// Namer wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func Namer(v cchat.Namer, t *Checker) cchat.Namer {
+	if v == nil {
+		return nil
+	}
+	return namer{v, t}
+}
+
+func (w namer) Name(ctx context.Context, a0 cchat.LabelContainer) (func(), error) {
+	w.t = w.t.enter("cchat.Namer.Name")
+	stop, err := w.v.Name(ctx, LabelContainer(a0, w.t))
+	return w.t.stopFunc(stop, err), err
+}
+
+type noncer struct {
+	v cchat.Noncer
+	t *Checker
+}
+
+// Noncer wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func Noncer(v cchat.Noncer, t *Checker) cchat.Noncer {
+	if v == nil {
+		return nil
+	}
+	return noncer{v, t}
+}
+
+func (w noncer) Nonce() string {
+	r0 := w.v.Nonce()
+	return r0
+}
+
+type user struct {
+	v cchat.User
+	t *Checker
+}
+
+// User wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func User(v cchat.User, t *Checker) cchat.User {
+	if v == nil {
+		return nil
+	}
+	return user{v, t}
+}
+
+func (w user) ID() cchat.ID {
+	r0 := w.v.ID()
+	return r0
+}
+
+func (w user) Name(ctx context.Context, a0 cchat.LabelContainer) (func(), error) {
+	w.t = w.t.enter("cchat.Namer.Name")
+	stop, err := w.v.Name(ctx, LabelContainer(a0, w.t))
+	return w.t.stopFunc(stop, err), err
+}
+
+type service struct {
+	v cchat.Service
+	t *Checker
+}
+
+// Service wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func Service(v cchat.Service, t *Checker) cchat.Service {
+	if v == nil {
+		return nil
+	}
+	return service{v, t}
+}
+
+func (w service) ID() cchat.ID {
+	r0 := w.v.ID()
+	return r0
+}
+
+func (w service) Name(ctx context.Context, a0 cchat.LabelContainer) (func(), error) {
+	w.t = w.t.enter("cchat.Namer.Name")
+	stop, err := w.v.Name(ctx, LabelContainer(a0, w.t))
+	return w.t.stopFunc(stop, err), err
+}
+
+func (w service) Authenticate() []cchat.Authenticator {
+	r0 := w.v.Authenticate()
+	return authenticatorSlice(r0, w.t)
+}
+
+func (w service) AsConfigurator() cchat.Configurator {
+	return Configurator(w.v.AsConfigurator(), w.t)
+}
+
+func (w service) AsSessionRestorer() cchat.SessionRestorer {
+	return SessionRestorer(w.v.AsSessionRestorer(), w.t)
+}
+
+type authenticateError struct {
+	v cchat.AuthenticateError
+	t *Checker
+}
+
+// AuthenticateError wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func AuthenticateError(v cchat.AuthenticateError, t *Checker) cchat.AuthenticateError {
+	if v == nil {
+		return nil
+	}
+	return authenticateError{v, t}
+}
+
+func (w authenticateError) Error() string {
+	r0 := w.v.Error()
+	return r0
+}
+
+func (w authenticateError) NextStage() []cchat.Authenticator {
+	r0 := w.v.NextStage()
+	return authenticatorSlice(r0, w.t)
+}
+
+type authenticator struct {
+	v cchat.Authenticator
+	t *Checker
+}
+
+// Authenticator wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func Authenticator(v cchat.Authenticator, t *Checker) cchat.Authenticator {
+	if v == nil {
+		return nil
+	}
+	return authenticator{v, t}
+}
+
+func (w authenticator) Name() text.Rich {
+	r0 := w.v.Name()
+	return r0
+}
+
+func (w authenticator) Description() text.Rich {
+	r0 := w.v.Description()
+	return r0
+}
+
+func (w authenticator) AuthenticateForm() []cchat.AuthenticateEntry {
+	r0 := w.v.AuthenticateForm()
+	return r0
+}
+
+func (w authenticator) Authenticate(ctx context.Context, a0 []string) (cchat.Session, cchat.AuthenticateError) {
+	w.t = w.t.enter("cchat.Authenticator.Authenticate")
+	defer w.t.stop()
+	r0, err := w.v.Authenticate(ctx, a0)
+	return Session(r0, w.t), err
+}
+
+type sessionRestorer struct {
+	v cchat.SessionRestorer
+	t *Checker
+}
+
+// SessionRestorer wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func SessionRestorer(v cchat.SessionRestorer, t *Checker) cchat.SessionRestorer {
+	if v == nil {
+		return nil
+	}
+	return sessionRestorer{v, t}
+}
+
+func (w sessionRestorer) RestoreSession(ctx context.Context, a0 map[string]string) (cchat.Session, error) {
+	w.t = w.t.enter("cchat.SessionRestorer.RestoreSession")
+	defer w.t.stop()
+	r0, err := w.v.RestoreSession(ctx, a0)
+	return Session(r0, w.t), err
+}
+
+type configurator struct {
+	v cchat.Configurator
+	t *Checker
+}
+
+// Configurator wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func Configurator(v cchat.Configurator, t *Checker) cchat.Configurator {
+	if v == nil {
+		return nil
+	}
+	return configurator{v, t}
+}
+
+func (w configurator) Configuration() map[string]string {
+	r0 := w.v.Configuration()
+	return r0
+}
+
+func (w configurator) SetConfiguration(a0 map[string]string) error {
+	err := w.v.SetConfiguration(a0)
+	return err
+}
+
+type session struct {
+	v cchat.Session
+	t *Checker
+}
+
+// Session wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func Session(v cchat.Session, t *Checker) cchat.Session {
+	if v == nil {
+		return nil
+	}
+	return session{v, t}
+}
+
+func (w session) ID() cchat.ID {
+	r0 := w.v.ID()
+	return r0
+}
+
+func (w session) Name(ctx context.Context, a0 cchat.LabelContainer) (func(), error) {
+	w.t = w.t.enter("cchat.Namer.Name")
+	stop, err := w.v.Name(ctx, LabelContainer(a0, w.t))
+	return w.t.stopFunc(stop, err), err
+}
+
+func (w session) Columnate() bool {
+	r0 := w.v.Columnate()
+	return r0
+}
+
+func (w session) Servers(a0 cchat.ServersContainer) (func(), error) {
+	w.t = w.t.enter("cchat.Lister.Servers")
+	stop, err := w.v.Servers(ServersContainer(a0, w.t))
+	return w.t.stopFunc(stop, err), err
+}
+
+func (w session) Disconnect(ctx context.Context) error {
+	w.t = w.t.enter("cchat.Session.Disconnect")
+	defer w.t.stop()
+	err := w.v.Disconnect(ctx)
+	return err
+}
+
+func (w session) AsCommander() cchat.Commander {
+	return Commander(w.v.AsCommander(), w.t)
+}
+
+func (w session) AsSessionSaver() cchat.SessionSaver {
+	return SessionSaver(w.v.AsSessionSaver(), w.t)
+}
+
+func (w session) AsPresenceSetter() cchat.PresenceSetter {
+	return PresenceSetter(w.v.AsPresenceSetter(), w.t)
+}
+
+func (w session) AsProfiler() cchat.Profiler {
+	return Profiler(w.v.AsProfiler(), w.t)
+}
+
+func (w session) AsDirectMessager() cchat.DirectMessager {
+	return DirectMessager(w.v.AsDirectMessager(), w.t)
+}
+
+func (w session) AsEmojier() cchat.Emojier {
+	return Emojier(w.v.AsEmojier(), w.t)
+}
+
+func (w session) AsConnectionStater() cchat.ConnectionStater {
+	return ConnectionStater(w.v.AsConnectionStater(), w.t)
+}
+
+type connectionStater struct {
+	v cchat.ConnectionStater
+	t *Checker
+}
+
+// ConnectionStater wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func ConnectionStater(v cchat.ConnectionStater, t *Checker) cchat.ConnectionStater {
+	if v == nil {
+		return nil
+	}
+	return connectionStater{v, t}
+}
+
+func (w connectionStater) ConnectionSubscribe(ctx context.Context, a0 cchat.ConnectionStateContainer) (func(), error) {
+	w.t = w.t.enter("cchat.ConnectionStater.ConnectionSubscribe")
+	stop, err := w.v.ConnectionSubscribe(ctx, ConnectionStateContainer(a0, w.t))
+	return w.t.stopFunc(stop, err), err
+}
+
+type directMessager struct {
+	v cchat.DirectMessager
+	t *Checker
+}
+
+// DirectMessager wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func DirectMessager(v cchat.DirectMessager, t *Checker) cchat.DirectMessager {
+	if v == nil {
+		return nil
+	}
+	return directMessager{v, t}
+}
+
+func (w directMessager) DirectMessage(ctx context.Context, a0 []cchat.ID) (cchat.Server, error) {
+	w.t = w.t.enter("cchat.DirectMessager.DirectMessage")
+	defer w.t.stop()
+	r0, err := w.v.DirectMessage(ctx, a0)
+	return Server(r0, w.t), err
+}
+
+type presenceSetter struct {
+	v cchat.PresenceSetter
+	t *Checker
+}
+
+// PresenceSetter wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func PresenceSetter(v cchat.PresenceSetter, t *Checker) cchat.PresenceSetter {
+	if v == nil {
+		return nil
+	}
+	return presenceSetter{v, t}
+}
+
+func (w presenceSetter) SetPresence(ctx context.Context, a0 cchat.Presence) error {
+	w.t = w.t.enter("cchat.PresenceSetter.SetPresence")
+	defer w.t.stop()
+	err := w.v.SetPresence(ctx, a0)
+	return err
+}
+
+func (w presenceSetter) PresenceSubscribe(ctx context.Context, a0 cchat.PresenceContainer) (func(), error) {
+	w.t = w.t.enter("cchat.PresenceSetter.PresenceSubscribe")
+	stop, err := w.v.PresenceSubscribe(ctx, PresenceContainer(a0, w.t))
+	return w.t.stopFunc(stop, err), err
+}
+
+type sessionSaver struct {
+	v cchat.SessionSaver
+	t *Checker
+}
+
+// SessionSaver wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func SessionSaver(v cchat.SessionSaver, t *Checker) cchat.SessionSaver {
+	if v == nil {
+		return nil
+	}
+	return sessionSaver{v, t}
+}
+
+func (w sessionSaver) SaveSession() map[string]string {
+	r0 := w.v.SaveSession()
+	return r0
+}
+
+type commander struct {
+	v cchat.Commander
+	t *Checker
+}
+
+// Commander wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func Commander(v cchat.Commander, t *Checker) cchat.Commander {
+	if v == nil {
+		return nil
+	}
+	return commander{v, t}
+}
+
+func (w commander) Run(ctx context.Context, a0 []string) ([]byte, error) {
+	w.t = w.t.enter("cchat.Commander.Run")
+	defer w.t.stop()
+	r0, err := w.v.Run(ctx, a0)
+	return r0, err
+}
+
+func (w commander) AsCompleter() cchat.Completer {
+	return Completer(w.v.AsCompleter(), w.t)
+}
+
+type server struct {
+	v cchat.Server
+	t *Checker
+}
+
+// Server wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func Server(v cchat.Server, t *Checker) cchat.Server {
+	if v == nil {
+		return nil
+	}
+	return server{v, t}
+}
+
+func (w server) ID() cchat.ID {
+	r0 := w.v.ID()
+	return r0
+}
+
+func (w server) Name(ctx context.Context, a0 cchat.LabelContainer) (func(), error) {
+	w.t = w.t.enter("cchat.Namer.Name")
+	stop, err := w.v.Name(ctx, LabelContainer(a0, w.t))
+	return w.t.stopFunc(stop, err), err
+}
+
+func (w server) AsLister() cchat.Lister {
+	return Lister(w.v.AsLister(), w.t)
+}
+
+func (w server) AsMessenger() cchat.Messenger {
+	return Messenger(w.v.AsMessenger(), w.t.messenger(w.v.ID()))
+}
+
+func (w server) AsCommander() cchat.Commander {
+	return Commander(w.v.AsCommander(), w.t)
+}
+
+func (w server) AsConfigurator() cchat.Configurator {
+	return Configurator(w.v.AsConfigurator(), w.t)
+}
+
+func (w server) AsNotificationSettings() cchat.NotificationSettings {
+	return NotificationSettings(w.v.AsNotificationSettings(), w.t)
+}
+
+type notificationSettings struct {
+	v cchat.NotificationSettings
+	t *Checker
+}
+
+// NotificationSettings wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func NotificationSettings(v cchat.NotificationSettings, t *Checker) cchat.NotificationSettings {
+	if v == nil {
+		return nil
+	}
+	return notificationSettings{v, t}
+}
+
+func (w notificationSettings) NotificationPreference() cchat.NotificationPreference {
+	r0 := w.v.NotificationPreference()
+	return r0
+}
+
+func (w notificationSettings) SetNotificationPreference(ctx context.Context, a0 cchat.NotificationPreference) error {
+	w.t = w.t.enter("cchat.NotificationSettings.SetNotificationPreference")
+	defer w.t.stop()
+	err := w.v.SetNotificationPreference(ctx, a0)
+	return err
+}
+
+type lister struct {
+	v cchat.Lister
+	t *Checker
+}
+
+// Lister wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func Lister(v cchat.Lister, t *Checker) cchat.Lister {
+	if v == nil {
+		return nil
+	}
+	return lister{v, t}
+}
+
+func (w lister) Columnate() bool {
+	r0 := w.v.Columnate()
+	return r0
+}
+
+func (w lister) Servers(a0 cchat.ServersContainer) (func(), error) {
+	w.t = w.t.enter("cchat.Lister.Servers")
+	stop, err := w.v.Servers(ServersContainer(a0, w.t))
+	return w.t.stopFunc(stop, err), err
+}
+
+type messenger struct {
+	v cchat.Messenger
+	t *Checker
+}
+
+// Messenger wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func Messenger(v cchat.Messenger, t *Checker) cchat.Messenger {
+	if v == nil {
+		return nil
+	}
+	return messenger{v, t}
+}
+
+func (w messenger) JoinServer(ctx context.Context, a0 cchat.MessagesContainer) (func(), error) {
+	w.t = w.t.enter("cchat.Messenger.JoinServer")
+	stop, err := w.v.JoinServer(ctx, MessagesContainer(a0, w.t))
+	return w.t.stopFunc(stop, err), err
+}
+
+func (w messenger) AsSender() cchat.Sender {
+	return Sender(w.v.AsSender(), w.t)
+}
+
+func (w messenger) AsEditor() cchat.Editor {
+	return Editor(w.v.AsEditor(), w.t)
+}
+
+func (w messenger) AsDeleter() cchat.Deleter {
+	return Deleter(w.v.AsDeleter(), w.t)
+}
+
+func (w messenger) AsActioner() cchat.Actioner {
+	return Actioner(w.v.AsActioner(), w.t)
+}
+
+func (w messenger) AsNicknamer() cchat.Nicknamer {
+	return Nicknamer(w.v.AsNicknamer(), w.t)
+}
+
+func (w messenger) AsBacklogger() cchat.Backlogger {
+	return Backlogger(w.v.AsBacklogger(), w.t)
+}
+
+func (w messenger) AsMemberLister() cchat.MemberLister {
+	return MemberLister(w.v.AsMemberLister(), w.t)
+}
+
+func (w messenger) AsReadIndicator() cchat.ReadIndicator {
+	return ReadIndicator(w.v.AsReadIndicator(), w.t)
+}
+
+func (w messenger) AsUnreadIndicator() cchat.UnreadIndicator {
+	return UnreadIndicator(w.v.AsUnreadIndicator(), w.t)
+}
+
+func (w messenger) AsTypingIndicator() cchat.TypingIndicator {
+	return TypingIndicator(w.v.AsTypingIndicator(), w.t)
+}
+
+func (w messenger) AsProfiler() cchat.Profiler {
+	return Profiler(w.v.AsProfiler(), w.t)
+}
+
+func (w messenger) AsPinner() cchat.Pinner {
+	return Pinner(w.v.AsPinner(), w.t)
+}
+
+func (w messenger) AsEmojier() cchat.Emojier {
+	return Emojier(w.v.AsEmojier(), w.t)
+}
+
+func (w messenger) AsDraftSyncer() cchat.DraftSyncer {
+	return DraftSyncer(w.v.AsDraftSyncer(), w.t)
+}
+
+type draftSyncer struct {
+	v cchat.DraftSyncer
+	t *Checker
+}
+
+// DraftSyncer wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func DraftSyncer(v cchat.DraftSyncer, t *Checker) cchat.DraftSyncer {
+	if v == nil {
+		return nil
+	}
+	return draftSyncer{v, t}
+}
+
+func (w draftSyncer) Draft(ctx context.Context) (cchat.Draft, error) {
+	w.t = w.t.enter("cchat.DraftSyncer.Draft")
+	defer w.t.stop()
+	r0, err := w.v.Draft(ctx)
+	return r0, err
+}
+
+func (w draftSyncer) SetDraft(ctx context.Context, a0 cchat.Draft) error {
+	w.t = w.t.enter("cchat.DraftSyncer.SetDraft")
+	defer w.t.stop()
+	err := w.v.SetDraft(ctx, a0)
+	return err
+}
+
+type emojier struct {
+	v cchat.Emojier
+	t *Checker
+}
+
+// Emojier wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func Emojier(v cchat.Emojier, t *Checker) cchat.Emojier {
+	if v == nil {
+		return nil
+	}
+	return emojier{v, t}
+}
+
+func (w emojier) Emojis(ctx context.Context) ([]cchat.EmojiGroup, error) {
+	w.t = w.t.enter("cchat.Emojier.Emojis")
+	defer w.t.stop()
+	r0, err := w.v.Emojis(ctx)
+	return r0, err
+}
+
+type sender struct {
+	v cchat.Sender
+	t *Checker
+}
+
+// Sender wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func Sender(v cchat.Sender, t *Checker) cchat.Sender {
+	if v == nil {
+		return nil
+	}
+	return sender{v, t}
+}
+
+func (w sender) Send(ctx context.Context, a0 cchat.SendableMessage) error {
+	w.t = w.t.enter("cchat.Sender.Send")
+	defer w.t.stop()
+	err := w.v.Send(ctx, SendableMessage(a0, w.t))
+	return err
+}
+
+func (w sender) CanAttach() bool {
+	r0 := w.v.CanAttach()
+	return r0
+}
+
+func (w sender) AsCompleter() cchat.Completer {
+	return Completer(w.v.AsCompleter(), w.t)
+}
+
+func (w sender) AsSendStateIndicator() cchat.SendStateIndicator {
+	return SendStateIndicator(w.v.AsSendStateIndicator(), w.t)
+}
+
+type sendStateIndicator struct {
+	v cchat.SendStateIndicator
+	t *Checker
+}
+
+// SendStateIndicator wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func SendStateIndicator(v cchat.SendStateIndicator, t *Checker) cchat.SendStateIndicator {
+	if v == nil {
+		return nil
+	}
+	return sendStateIndicator{v, t}
+}
+
+func (w sendStateIndicator) SendStateIndicate(ctx context.Context, a0 cchat.SendStateContainer) (func(), error) {
+	w.t = w.t.enter("cchat.SendStateIndicator.SendStateIndicate")
+	stop, err := w.v.SendStateIndicate(ctx, SendStateContainer(a0, w.t))
+	return w.t.stopFunc(stop, err), err
+}
+
+type editor struct {
+	v cchat.Editor
+	t *Checker
+}
+
+// Editor wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func Editor(v cchat.Editor, t *Checker) cchat.Editor {
+	if v == nil {
+		return nil
+	}
+	return editor{v, t}
+}
+
+func (w editor) IsEditable(a0 cchat.ID) bool {
+	r0 := w.v.IsEditable(a0)
+	return r0
+}
+
+func (w editor) RawContent(a0 cchat.ID) (string, error) {
+	r0, err := w.v.RawContent(a0)
+	return r0, err
+}
+
+func (w editor) Edit(ctx context.Context, a0 cchat.ID, a1 string) error {
+	w.t = w.t.enter("cchat.Editor.Edit")
+	defer w.t.stop()
+	err := w.v.Edit(ctx, a0, a1)
+	return err
+}
+
+type deleter struct {
+	v cchat.Deleter
+	t *Checker
+}
+
+// Deleter wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func Deleter(v cchat.Deleter, t *Checker) cchat.Deleter {
+	if v == nil {
+		return nil
+	}
+	return deleter{v, t}
+}
+
+func (w deleter) IsDeletable(a0 cchat.ID) bool {
+	r0 := w.v.IsDeletable(a0)
+	return r0
+}
+
+func (w deleter) Delete(ctx context.Context, a0 cchat.ID) error {
+	w.t = w.t.enter("cchat.Deleter.Delete")
+	defer w.t.stop()
+	err := w.v.Delete(ctx, a0)
+	return err
+}
+
+type pinner struct {
+	v cchat.Pinner
+	t *Checker
+}
+
+// Pinner wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func Pinner(v cchat.Pinner, t *Checker) cchat.Pinner {
+	if v == nil {
+		return nil
+	}
+	return pinner{v, t}
+}
+
+func (w pinner) IsPinnable(a0 cchat.ID) bool {
+	r0 := w.v.IsPinnable(a0)
+	return r0
+}
+
+func (w pinner) Pin(ctx context.Context, a0 cchat.ID) error {
+	w.t = w.t.enter("cchat.Pinner.Pin")
+	defer w.t.stop()
+	err := w.v.Pin(ctx, a0)
+	return err
+}
+
+func (w pinner) Unpin(ctx context.Context, a0 cchat.ID) error {
+	w.t = w.t.enter("cchat.Pinner.Unpin")
+	defer w.t.stop()
+	err := w.v.Unpin(ctx, a0)
+	return err
+}
+
+func (w pinner) Pins(ctx context.Context, a0 cchat.MessagesContainer) (func(), error) {
+	w.t = w.t.enter("cchat.Pinner.Pins")
+	stop, err := w.v.Pins(ctx, MessagesContainer(a0, w.t))
+	return w.t.stopFunc(stop, err), err
+}
+
+type actioner struct {
+	v cchat.Actioner
+	t *Checker
+}
+
+// Actioner wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func Actioner(v cchat.Actioner, t *Checker) cchat.Actioner {
+	if v == nil {
+		return nil
+	}
+	return actioner{v, t}
+}
+
+func (w actioner) Actions(a0 cchat.ID) []string {
+	r0 := w.v.Actions(a0)
+	return r0
+}
+
+func (w actioner) Do(ctx context.Context, a0 string, a1 cchat.ID) error {
+	w.t = w.t.enter("cchat.Actioner.Do")
+	defer w.t.stop()
+	err := w.v.Do(ctx, a0, a1)
+	return err
+}
+
+func (w actioner) AsActionDescriber() cchat.ActionDescriber {
+	return ActionDescriber(w.v.AsActionDescriber(), w.t)
+}
+
+type actionDescriber struct {
+	v cchat.ActionDescriber
+	t *Checker
+}
+
+// ActionDescriber wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func ActionDescriber(v cchat.ActionDescriber, t *Checker) cchat.ActionDescriber {
+	if v == nil {
+		return nil
+	}
+	return actionDescriber{v, t}
+}
+
+func (w actionDescriber) DescribeActions(a0 cchat.ID) []cchat.ActionDescriptor {
+	r0 := w.v.DescribeActions(a0)
+	return r0
+}
+
+func (w actionDescriber) DoInput(ctx context.Context, a0 string, a1 cchat.ID, a2 []string) error {
+	w.t = w.t.enter("cchat.ActionDescriber.DoInput")
+	defer w.t.stop()
+	err := w.v.DoInput(ctx, a0, a1, a2)
+	return err
+}
+
+type nicknamer struct {
+	v cchat.Nicknamer
+	t *Checker
+}
+
+// Nicknamer wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func Nicknamer(v cchat.Nicknamer, t *Checker) cchat.Nicknamer {
+	if v == nil {
+		return nil
+	}
+	return nicknamer{v, t}
+}
+
+func (w nicknamer) Name(ctx context.Context, a0 cchat.LabelContainer) (func(), error) {
+	w.t = w.t.enter("cchat.Namer.Name")
+	stop, err := w.v.Name(ctx, LabelContainer(a0, w.t))
+	return w.t.stopFunc(stop, err), err
+}
+
+type backlogger struct {
+	v cchat.Backlogger
+	t *Checker
+}
+
+// Backlogger wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func Backlogger(v cchat.Backlogger, t *Checker) cchat.Backlogger {
+	if v == nil {
+		return nil
+	}
+	return backlogger{v, t}
+}
+
+func (w backlogger) Backlog(ctx context.Context, a0 cchat.ID, a1 cchat.MessagesContainer) error {
+	w.t = w.t.enter("cchat.Backlogger.Backlog")
+	defer w.t.stop()
+	err := w.v.Backlog(ctx, a0, MessagesContainer(a1, w.t))
+	return err
+}
+
+type memberLister struct {
+	v cchat.MemberLister
+	t *Checker
+}
+
+// MemberLister wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func MemberLister(v cchat.MemberLister, t *Checker) cchat.MemberLister {
+	if v == nil {
+		return nil
+	}
+	return memberLister{v, t}
+}
+
+func (w memberLister) ListMembers(ctx context.Context, a0 cchat.MemberListContainer) (func(), error) {
+	w.t = w.t.enter("cchat.MemberLister.ListMembers")
+	stop, err := w.v.ListMembers(ctx, MemberListContainer(a0, w.t))
+	return w.t.stopFunc(stop, err), err
+}
+
+type readIndicator struct {
+	v cchat.ReadIndicator
+	t *Checker
+}
+
+// ReadIndicator wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func ReadIndicator(v cchat.ReadIndicator, t *Checker) cchat.ReadIndicator {
+	if v == nil {
+		return nil
+	}
+	return readIndicator{v, t}
+}
+
+func (w readIndicator) ReadIndicate(ctx context.Context, a0 cchat.ReadContainer) (func(), error) {
+	w.t = w.t.enter("cchat.ReadIndicator.ReadIndicate")
+	stop, err := w.v.ReadIndicate(ctx, ReadContainer(a0, w.t))
+	return w.t.stopFunc(stop, err), err
+}
+
+type unreadIndicator struct {
+	v cchat.UnreadIndicator
+	t *Checker
+}
+
+// UnreadIndicator wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func UnreadIndicator(v cchat.UnreadIndicator, t *Checker) cchat.UnreadIndicator {
+	if v == nil {
+		return nil
+	}
+	return unreadIndicator{v, t}
+}
+
+func (w unreadIndicator) MarkRead(ctx context.Context, a0 cchat.ID) {
+	w.v.MarkRead(ctx, a0)
+}
+
+func (w unreadIndicator) UnreadIndicate(ctx context.Context, a0 cchat.UnreadContainer) (func(), error) {
+	w.t = w.t.enter("cchat.UnreadIndicator.UnreadIndicate")
+	stop, err := w.v.UnreadIndicate(ctx, UnreadContainer(a0, w.t))
+	return w.t.stopFunc(stop, err), err
+}
+
+type typingIndicator struct {
+	v cchat.TypingIndicator
+	t *Checker
+}
+
+// TypingIndicator wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func TypingIndicator(v cchat.TypingIndicator, t *Checker) cchat.TypingIndicator {
+	if v == nil {
+		return nil
+	}
+	return typingIndicator{v, t}
+}
+
+func (w typingIndicator) Typing(ctx context.Context) error {
+	w.t = w.t.enter("cchat.TypingIndicator.Typing")
+	defer w.t.stop()
+	err := w.v.Typing(ctx)
+	return err
+}
+
+func (w typingIndicator) TypingTimeout() time.Duration {
+	r0 := w.v.TypingTimeout()
+	return r0
+}
+
+func (w typingIndicator) TypingSubscribe(ctx context.Context, a0 cchat.TypingContainer) (func(), error) {
+	w.t = w.t.enter("cchat.TypingIndicator.TypingSubscribe")
+	stop, err := w.v.TypingSubscribe(ctx, TypingContainer(a0, w.t))
+	return w.t.stopFunc(stop, err), err
+}
+
+type profiler struct {
+	v cchat.Profiler
+	t *Checker
+}
+
+// Profiler wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func Profiler(v cchat.Profiler, t *Checker) cchat.Profiler {
+	if v == nil {
+		return nil
+	}
+	return profiler{v, t}
+}
+
+func (w profiler) Profile(ctx context.Context, a0 cchat.ID, a1 cchat.ProfileContainer) error {
+	w.t = w.t.enter("cchat.Profiler.Profile")
+	defer w.t.stop()
+	err := w.v.Profile(ctx, a0, ProfileContainer(a1, w.t))
+	return err
+}
+
+type completer struct {
+	v cchat.Completer
+	t *Checker
+}
+
+// Completer wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func Completer(v cchat.Completer, t *Checker) cchat.Completer {
+	if v == nil {
+		return nil
+	}
+	return completer{v, t}
+}
+
+func (w completer) Complete(a0 []string, a1 int64) []cchat.CompletionEntry {
+	r0 := w.v.Complete(a0, a1)
+	return r0
+}
+
+type serversContainer struct {
+	v cchat.ServersContainer
+	t *Checker
+}
+
+// ServersContainer wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func ServersContainer(v cchat.ServersContainer, t *Checker) cchat.ServersContainer {
+	if v == nil {
+		return nil
+	}
+	return serversContainer{v, t}
+}
+
+func (w serversContainer) SetServers(ctx context.Context, a0 []cchat.Server) {
+	const method = "cchat.ServersContainer.SetServers"
+	if !w.t.call(method) || !w.t.setServers(method, a0) {
+		return
+	}
+	w.v.SetServers(ctx, serverSlice(a0, w.t))
+}
+
+func (w serversContainer) UpdateServer(ctx context.Context, a0 cchat.ServerUpdate) {
+	const method = "cchat.ServersContainer.UpdateServer"
+	if !w.t.call(method) || !w.t.updateServer(method, a0) {
+		return
+	}
+	w.v.UpdateServer(ctx, ServerUpdate(a0, w.t))
+}
+
+type serverUpdate struct {
+	v cchat.ServerUpdate
+	t *Checker
+}
+
+// ServerUpdate wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func ServerUpdate(v cchat.ServerUpdate, t *Checker) cchat.ServerUpdate {
+	if v == nil {
+		return nil
+	}
+	return serverUpdate{v, t}
+}
+
+func (w serverUpdate) ID() cchat.ID {
+	r0 := w.v.ID()
+	return r0
+}
+
+func (w serverUpdate) Name(ctx context.Context, a0 cchat.LabelContainer) (func(), error) {
+	w.t = w.t.enter("cchat.Namer.Name")
+	stop, err := w.v.Name(ctx, LabelContainer(a0, w.t))
+	return w.t.stopFunc(stop, err), err
+}
+
+func (w serverUpdate) AsLister() cchat.Lister {
+	return Lister(w.v.AsLister(), w.t)
+}
+
+func (w serverUpdate) AsMessenger() cchat.Messenger {
+	return Messenger(w.v.AsMessenger(), w.t.messenger(w.v.ID()))
+}
+
+func (w serverUpdate) AsCommander() cchat.Commander {
+	return Commander(w.v.AsCommander(), w.t)
+}
+
+func (w serverUpdate) AsConfigurator() cchat.Configurator {
+	return Configurator(w.v.AsConfigurator(), w.t)
+}
+
+func (w serverUpdate) AsNotificationSettings() cchat.NotificationSettings {
+	return NotificationSettings(w.v.AsNotificationSettings(), w.t)
+}
+
+func (w serverUpdate) PreviousID() (cchat.ID, bool) {
+	r0, r1 := w.v.PreviousID()
+	return r0, r1
+}
+
+type messagesContainer struct {
+	v cchat.MessagesContainer
+	t *Checker
+}
+
+// MessagesContainer wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func MessagesContainer(v cchat.MessagesContainer, t *Checker) cchat.MessagesContainer {
+	if v == nil {
+		return nil
+	}
+	return messagesContainer{v, t}
+}
+
+func (w messagesContainer) CreateMessage(ctx context.Context, a0 cchat.MessageCreate) {
+	const method = "cchat.MessagesContainer.CreateMessage"
+	if !w.t.call(method) || !w.t.createMessage(method, a0) {
+		return
+	}
+	w.v.CreateMessage(ctx, MessageCreate(a0, w.t))
+}
+
+func (w messagesContainer) UpdateMessage(ctx context.Context, a0 cchat.MessageUpdate) {
+	const method = "cchat.MessagesContainer.UpdateMessage"
+	if !w.t.call(method) || !w.t.updateMessage(method, a0) {
+		return
+	}
+	w.v.UpdateMessage(ctx, MessageUpdate(a0, w.t))
+}
+
+func (w messagesContainer) DeleteMessage(ctx context.Context, a0 cchat.MessageDelete) {
+	const method = "cchat.MessagesContainer.DeleteMessage"
+	if !w.t.call(method) || !w.t.deleteMessage(method, a0) {
+		return
+	}
+	w.v.DeleteMessage(ctx, MessageDelete(a0, w.t))
+}
+
+type messageHeader struct {
+	v cchat.MessageHeader
+	t *Checker
+}
+
+// MessageHeader wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func MessageHeader(v cchat.MessageHeader, t *Checker) cchat.MessageHeader {
+	if v == nil {
+		return nil
+	}
+	return messageHeader{v, t}
+}
+
+func (w messageHeader) ID() cchat.ID {
+	r0 := w.v.ID()
+	return r0
+}
+
+func (w messageHeader) Time() time.Time {
+	r0 := w.v.Time()
+	return r0
+}
+
+type messageCreate struct {
+	v cchat.MessageCreate
+	t *Checker
+}
+
+// MessageCreate wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func MessageCreate(v cchat.MessageCreate, t *Checker) cchat.MessageCreate {
+	if v == nil {
+		return nil
+	}
+	return messageCreate{v, t}
+}
+
+func (w messageCreate) ID() cchat.ID {
+	r0 := w.v.ID()
+	return r0
+}
+
+func (w messageCreate) Time() time.Time {
+	r0 := w.v.Time()
+	return r0
+}
+
+func (w messageCreate) Nonce() string {
+	r0 := w.v.Nonce()
+	return r0
+}
+
+func (w messageCreate) Author() cchat.User {
+	r0 := w.v.Author()
+	return User(r0, w.t)
+}
+
+func (w messageCreate) Content() text.Rich {
+	r0 := w.v.Content()
+	return r0
+}
+
+func (w messageCreate) Mentioned() bool {
+	r0 := w.v.Mentioned()
+	return r0
+}
+
+func (w messageCreate) AsMassMentioner() cchat.MassMentioner {
+	return MassMentioner(w.v.AsMassMentioner(), w.t)
+}
+
+type massMentioner struct {
+	v cchat.MassMentioner
+	t *Checker
+}
+
+// MassMentioner wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func MassMentioner(v cchat.MassMentioner, t *Checker) cchat.MassMentioner {
+	if v == nil {
+		return nil
+	}
+	return massMentioner{v, t}
+}
+
+func (w massMentioner) MassMentioned() bool {
+	r0 := w.v.MassMentioned()
+	return r0
+}
+
+type messageUpdate struct {
+	v cchat.MessageUpdate
+	t *Checker
+}
+
+// MessageUpdate wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func MessageUpdate(v cchat.MessageUpdate, t *Checker) cchat.MessageUpdate {
+	if v == nil {
+		return nil
+	}
+	return messageUpdate{v, t}
+}
+
+func (w messageUpdate) ID() cchat.ID {
+	r0 := w.v.ID()
+	return r0
+}
+
+func (w messageUpdate) Time() time.Time {
+	r0 := w.v.Time()
+	return r0
+}
+
+func (w messageUpdate) Content() text.Rich {
+	r0 := w.v.Content()
+	return r0
+}
+
+type messageDelete struct {
+	v cchat.MessageDelete
+	t *Checker
+}
+
+// MessageDelete wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func MessageDelete(v cchat.MessageDelete, t *Checker) cchat.MessageDelete {
+	if v == nil {
+		return nil
+	}
+	return messageDelete{v, t}
+}
+
+func (w messageDelete) ID() cchat.ID {
+	r0 := w.v.ID()
+	return r0
+}
+
+func (w messageDelete) Time() time.Time {
+	r0 := w.v.Time()
+	return r0
+}
+
+type labelContainer struct {
+	v cchat.LabelContainer
+	t *Checker
+}
+
+// LabelContainer wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func LabelContainer(v cchat.LabelContainer, t *Checker) cchat.LabelContainer {
+	if v == nil {
+		return nil
+	}
+	return labelContainer{v, t}
+}
+
+func (w labelContainer) SetLabel(ctx context.Context, a0 text.Rich) {
+	const method = "cchat.LabelContainer.SetLabel"
+	if !w.t.call(method) || !w.t.checkRich(method, a0) {
+		return
+	}
+	w.v.SetLabel(ctx, a0)
+}
+
+type profileContainer struct {
+	v cchat.ProfileContainer
+	t *Checker
+}
+
+// ProfileContainer wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func ProfileContainer(v cchat.ProfileContainer, t *Checker) cchat.ProfileContainer {
+	if v == nil {
+		return nil
+	}
+	return profileContainer{v, t}
+}
+
+func (w profileContainer) SetAvatar(ctx context.Context, a0 string) {
+	const method = "cchat.ProfileContainer.SetAvatar"
+	if !w.t.call(method) {
+		return
+	}
+	w.v.SetAvatar(ctx, a0)
+}
+
+func (w profileContainer) SetDisplayName(ctx context.Context, a0 text.Rich) {
+	const method = "cchat.ProfileContainer.SetDisplayName"
+	if !w.t.call(method) || !w.t.checkRich(method, a0) {
+		return
+	}
+	w.v.SetDisplayName(ctx, a0)
+}
+
+func (w profileContainer) SetBio(ctx context.Context, a0 text.Rich) {
+	const method = "cchat.ProfileContainer.SetBio"
+	if !w.t.call(method) || !w.t.checkRich(method, a0) {
+		return
+	}
+	w.v.SetBio(ctx, a0)
+}
+
+func (w profileContainer) SetStatus(ctx context.Context, a0 cchat.Status, a1 text.Rich) {
+	const method = "cchat.ProfileContainer.SetStatus"
+	if !w.t.call(method) || !w.t.checkRich(method, a1) {
+		return
+	}
+	w.v.SetStatus(ctx, a0, a1)
+}
+
+func (w profileContainer) SetRoles(ctx context.Context, a0 []cchat.Role) {
+	const method = "cchat.ProfileContainer.SetRoles"
+	if !w.t.call(method) {
+		return
+	}
+	w.v.SetRoles(ctx, a0)
+}
+
+func (w profileContainer) SetMutualServers(ctx context.Context, a0 []cchat.Server) {
+	const method = "cchat.ProfileContainer.SetMutualServers"
+	if !w.t.call(method) {
+		return
+	}
+	w.v.SetMutualServers(ctx, serverSlice(a0, w.t))
+}
+
+type readContainer struct {
+	v cchat.ReadContainer
+	t *Checker
+}
+
+// ReadContainer wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func ReadContainer(v cchat.ReadContainer, t *Checker) cchat.ReadContainer {
+	if v == nil {
+		return nil
+	}
+	return readContainer{v, t}
+}
+
+func (w readContainer) AddIndications(ctx context.Context, a0 []cchat.ReadIndication) {
+	const method = "cchat.ReadContainer.AddIndications"
+	if !w.t.call(method) {
+		return
+	}
+	w.v.AddIndications(ctx, a0)
+}
+
+func (w readContainer) DeleteIndications(ctx context.Context, a0 []cchat.ID) {
+	const method = "cchat.ReadContainer.DeleteIndications"
+	if !w.t.call(method) {
+		return
+	}
+	w.v.DeleteIndications(ctx, a0)
+}
+
+type unreadContainer struct {
+	v cchat.UnreadContainer
+	t *Checker
+}
+
+// UnreadContainer wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func UnreadContainer(v cchat.UnreadContainer, t *Checker) cchat.UnreadContainer {
+	if v == nil {
+		return nil
+	}
+	return unreadContainer{v, t}
+}
+
+func (w unreadContainer) SetUnread(ctx context.Context, a0 bool, a1 bool) {
+	const method = "cchat.UnreadContainer.SetUnread"
+	if !w.t.call(method) {
+		return
+	}
+	w.v.SetUnread(ctx, a0, a1)
+}
+
+func (w unreadContainer) AsUnreadCountContainer() cchat.UnreadCountContainer {
+	return UnreadCountContainer(w.v.AsUnreadCountContainer(), w.t)
+}
+
+type unreadCountContainer struct {
+	v cchat.UnreadCountContainer
+	t *Checker
+}
+
+// UnreadCountContainer wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func UnreadCountContainer(v cchat.UnreadCountContainer, t *Checker) cchat.UnreadCountContainer {
+	if v == nil {
+		return nil
+	}
+	return unreadCountContainer{v, t}
+}
+
+func (w unreadCountContainer) SetUnreadCount(ctx context.Context, a0 int, a1 int) {
+	const method = "cchat.UnreadCountContainer.SetUnreadCount"
+	if !w.t.call(method) {
+		return
+	}
+	w.v.SetUnreadCount(ctx, a0, a1)
+}
+
+type connectionStateContainer struct {
+	v cchat.ConnectionStateContainer
+	t *Checker
+}
+
+// ConnectionStateContainer wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func ConnectionStateContainer(v cchat.ConnectionStateContainer, t *Checker) cchat.ConnectionStateContainer {
+	if v == nil {
+		return nil
+	}
+	return connectionStateContainer{v, t}
+}
+
+func (w connectionStateContainer) SetConnectionState(ctx context.Context, a0 cchat.ConnectionState, a1 error) {
+	const method = "cchat.ConnectionStateContainer.SetConnectionState"
+	if !w.t.call(method) {
+		return
+	}
+	w.v.SetConnectionState(ctx, a0, a1)
+}
+
+func (w connectionStateContainer) SetLatency(ctx context.Context, a0 time.Duration) {
+	const method = "cchat.ConnectionStateContainer.SetLatency"
+	if !w.t.call(method) {
+		return
+	}
+	w.v.SetLatency(ctx, a0)
+}
+
+type presenceContainer struct {
+	v cchat.PresenceContainer
+	t *Checker
+}
+
+// PresenceContainer wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func PresenceContainer(v cchat.PresenceContainer, t *Checker) cchat.PresenceContainer {
+	if v == nil {
+		return nil
+	}
+	return presenceContainer{v, t}
+}
+
+func (w presenceContainer) SetPresence(ctx context.Context, a0 cchat.Presence) {
+	const method = "cchat.PresenceContainer.SetPresence"
+	if !w.t.call(method) {
+		return
+	}
+	w.v.SetPresence(ctx, a0)
+}
+
+type sendStateContainer struct {
+	v cchat.SendStateContainer
+	t *Checker
+}
+
+// SendStateContainer wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func SendStateContainer(v cchat.SendStateContainer, t *Checker) cchat.SendStateContainer {
+	if v == nil {
+		return nil
+	}
+	return sendStateContainer{v, t}
+}
+
+func (w sendStateContainer) SetSendState(ctx context.Context, a0 string, a1 cchat.SendState, a2 error) {
+	const method = "cchat.SendStateContainer.SetSendState"
+	if !w.t.call(method) {
+		return
+	}
+	w.v.SetSendState(ctx, a0, a1, a2)
+}
+
+type typingContainer struct {
+	v cchat.TypingContainer
+	t *Checker
+}
+
+// TypingContainer wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func TypingContainer(v cchat.TypingContainer, t *Checker) cchat.TypingContainer {
+	if v == nil {
+		return nil
+	}
+	return typingContainer{v, t}
+}
+
+func (w typingContainer) AddTyper(ctx context.Context, a0 cchat.User) {
+	const method = "cchat.TypingContainer.AddTyper"
+	if !w.t.call(method) {
+		return
+	}
+	w.v.AddTyper(ctx, User(a0, w.t))
+}
+
+func (w typingContainer) RemoveTyper(ctx context.Context, a0 cchat.ID) {
+	const method = "cchat.TypingContainer.RemoveTyper"
+	if !w.t.call(method) {
+		return
+	}
+	w.v.RemoveTyper(ctx, a0)
+}
+
+type memberListContainer struct {
+	v cchat.MemberListContainer
+	t *Checker
+}
+
+// MemberListContainer wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func MemberListContainer(v cchat.MemberListContainer, t *Checker) cchat.MemberListContainer {
+	if v == nil {
+		return nil
+	}
+	return memberListContainer{v, t}
+}
+
+func (w memberListContainer) SetSections(ctx context.Context, a0 []cchat.MemberSection) {
+	const method = "cchat.MemberListContainer.SetSections"
+	if !w.t.call(method) {
+		return
+	}
+	w.v.SetSections(ctx, memberSectionSlice(a0, w.t))
+}
+
+func (w memberListContainer) SetMember(ctx context.Context, a0 cchat.ID, a1 cchat.ListMember) {
+	const method = "cchat.MemberListContainer.SetMember"
+	if !w.t.call(method) {
+		return
+	}
+	w.v.SetMember(ctx, a0, ListMember(a1, w.t))
+}
+
+func (w memberListContainer) RemoveMember(ctx context.Context, a0 cchat.ID, a1 cchat.ID) {
+	const method = "cchat.MemberListContainer.RemoveMember"
+	if !w.t.call(method) {
+		return
+	}
+	w.v.RemoveMember(ctx, a0, a1)
+}
+
+type listMember struct {
+	v cchat.ListMember
+	t *Checker
+}
+
+// ListMember wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func ListMember(v cchat.ListMember, t *Checker) cchat.ListMember {
+	if v == nil {
+		return nil
+	}
+	return listMember{v, t}
+}
+
+func (w listMember) ID() cchat.ID {
+	r0 := w.v.ID()
+	return r0
+}
+
+func (w listMember) Name() text.Rich {
+	r0 := w.v.Name()
+	return r0
+}
+
+func (w listMember) Status() cchat.Status {
+	r0 := w.v.Status()
+	return r0
+}
+
+func (w listMember) Secondary() text.Rich {
+	r0 := w.v.Secondary()
+	return r0
+}
+
+func (w listMember) AsDirectMessager() cchat.DirectMessager {
+	return DirectMessager(w.v.AsDirectMessager(), w.t)
+}
+
+type memberSection struct {
+	v cchat.MemberSection
+	t *Checker
+}
+
+// MemberSection wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func MemberSection(v cchat.MemberSection, t *Checker) cchat.MemberSection {
+	if v == nil {
+		return nil
+	}
+	return memberSection{v, t}
+}
+
+func (w memberSection) ID() cchat.ID {
+	r0 := w.v.ID()
+	return r0
+}
+
+func (w memberSection) Name(ctx context.Context, a0 cchat.LabelContainer) (func(), error) {
+	w.t = w.t.enter("cchat.Namer.Name")
+	stop, err := w.v.Name(ctx, LabelContainer(a0, w.t))
+	return w.t.stopFunc(stop, err), err
+}
+
+func (w memberSection) Total() int {
+	r0 := w.v.Total()
+	return r0
+}
+
+func (w memberSection) AsMemberDynamicSection() cchat.MemberDynamicSection {
+	return MemberDynamicSection(w.v.AsMemberDynamicSection(), w.t)
+}
+
+type memberDynamicSection struct {
+	v cchat.MemberDynamicSection
+	t *Checker
+}
+
+// MemberDynamicSection wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func MemberDynamicSection(v cchat.MemberDynamicSection, t *Checker) cchat.MemberDynamicSection {
+	if v == nil {
+		return nil
+	}
+	return memberDynamicSection{v, t}
+}
+
+func (w memberDynamicSection) LoadMore(ctx context.Context) bool {
+	w.t = w.t.enter("cchat.MemberDynamicSection.LoadMore")
+	defer w.t.stop()
+	r0 := w.v.LoadMore(ctx)
+	return r0
+}
+
+func (w memberDynamicSection) LoadLess(ctx context.Context) bool {
+	w.t = w.t.enter("cchat.MemberDynamicSection.LoadLess")
+	defer w.t.stop()
+	r0 := w.v.LoadLess(ctx)
+	return r0
+}
+
+type sendableMessage struct {
+	v cchat.SendableMessage
+	t *Checker
+}
+
+// SendableMessage wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func SendableMessage(v cchat.SendableMessage, t *Checker) cchat.SendableMessage {
+	if v == nil {
+		return nil
+	}
+	return sendableMessage{v, t}
+}
+
+func (w sendableMessage) Content() string {
+	r0 := w.v.Content()
+	return r0
+}
+
+func (w sendableMessage) AsNoncer() cchat.Noncer {
+	return Noncer(w.v.AsNoncer(), w.t)
+}
+
+func (w sendableMessage) AsReplier() cchat.Replier {
+	return Replier(w.v.AsReplier(), w.t)
+}
+
+func (w sendableMessage) AsAttacher() cchat.Attacher {
+	return Attacher(w.v.AsAttacher(), w.t)
+}
+
+type replier struct {
+	v cchat.Replier
+	t *Checker
+}
+
+// Replier wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func Replier(v cchat.Replier, t *Checker) cchat.Replier {
+	if v == nil {
+		return nil
+	}
+	return replier{v, t}
+}
+
+func (w replier) ReplyingTo() cchat.ID {
+	r0 := w.v.ReplyingTo()
+	return r0
+}
+
+type attacher struct {
+	v cchat.Attacher
+	t *Checker
+}
+
+// Attacher wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func Attacher(v cchat.Attacher, t *Checker) cchat.Attacher {
+	if v == nil {
+		return nil
+	}
+	return attacher{v, t}
+}
+
+func (w attacher) Attachments() []cchat.MessageAttachment {
+	r0 := w.v.Attachments()
+	return r0
+}
+
+func (w attacher) AsUploadProgressContainer() cchat.UploadProgressContainer {
+	return UploadProgressContainer(w.v.AsUploadProgressContainer(), w.t)
+}
+
+type uploadProgressContainer struct {
+	v cchat.UploadProgressContainer
+	t *Checker
+}
+
+// UploadProgressContainer wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func UploadProgressContainer(v cchat.UploadProgressContainer, t *Checker) cchat.UploadProgressContainer {
+	if v == nil {
+		return nil
+	}
+	return uploadProgressContainer{v, t}
+}
+
+func (w uploadProgressContainer) SetUploadProgress(ctx context.Context, a0 int, a1 int64, a2 int64) {
+	const method = "cchat.UploadProgressContainer.SetUploadProgress"
+	if !w.t.call(method) {
+		return
+	}
+	w.v.SetUploadProgress(ctx, a0, a1, a2)
+}
+
+type textSegment struct {
+	v text.Segment
+	t *Checker
+}
+
+// TextSegment wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func TextSegment(v text.Segment, t *Checker) text.Segment {
+	if v == nil {
+		return nil
+	}
+	return textSegment{v, t}
+}
+
+func (w textSegment) Bounds() (int, int) {
+	r0, r1 := w.v.Bounds()
+	return r0, r1
+}
+
+func (w textSegment) AsColorer() text.Colorer {
+	return TextColorer(w.v.AsColorer(), w.t)
+}
+
+func (w textSegment) AsLinker() text.Linker {
+	return TextLinker(w.v.AsLinker(), w.t)
+}
+
+func (w textSegment) AsImager() text.Imager {
+	return TextImager(w.v.AsImager(), w.t)
+}
+
+func (w textSegment) AsAvatarer() text.Avatarer {
+	return TextAvatarer(w.v.AsAvatarer(), w.t)
+}
+
+func (w textSegment) AsMentioner() text.Mentioner {
+	return TextMentioner(w.v.AsMentioner(), w.t)
+}
+
+func (w textSegment) AsAttributor() text.Attributor {
+	return TextAttributor(w.v.AsAttributor(), w.t)
+}
+
+func (w textSegment) AsCodeblocker() text.Codeblocker {
+	return TextCodeblocker(w.v.AsCodeblocker(), w.t)
+}
+
+func (w textSegment) AsQuoteblocker() text.Quoteblocker {
+	return TextQuoteblocker(w.v.AsQuoteblocker(), w.t)
+}
+
+func (w textSegment) AsMessageReferencer() text.MessageReferencer {
+	return TextMessageReferencer(w.v.AsMessageReferencer(), w.t)
+}
+
+type textMessageReferencer struct {
+	v text.MessageReferencer
+	t *Checker
+}
+
+// TextMessageReferencer wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func TextMessageReferencer(v text.MessageReferencer, t *Checker) text.MessageReferencer {
+	if v == nil {
+		return nil
+	}
+	return textMessageReferencer{v, t}
+}
+
+func (w textMessageReferencer) MessageID() string {
+	r0 := w.v.MessageID()
+	return r0
+}
+
+type textLinker struct {
+	v text.Linker
+	t *Checker
+}
+
+// TextLinker wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func TextLinker(v text.Linker, t *Checker) text.Linker {
+	if v == nil {
+		return nil
+	}
+	return textLinker{v, t}
+}
+
+func (w textLinker) Link() string {
+	r0 := w.v.Link()
+	return r0
+}
+
+type textImager struct {
+	v text.Imager
+	t *Checker
+}
+
+// TextImager wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func TextImager(v text.Imager, t *Checker) text.Imager {
+	if v == nil {
+		return nil
+	}
+	return textImager{v, t}
+}
+
+func (w textImager) Image() string {
+	r0 := w.v.Image()
+	return r0
+}
+
+func (w textImager) ImageSize() (int, int) {
+	r0, r1 := w.v.ImageSize()
+	return r0, r1
+}
+
+func (w textImager) ImageText() string {
+	r0 := w.v.ImageText()
+	return r0
+}
+
+type textAvatarer struct {
+	v text.Avatarer
+	t *Checker
+}
+
+// TextAvatarer wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func TextAvatarer(v text.Avatarer, t *Checker) text.Avatarer {
+	if v == nil {
+		return nil
+	}
+	return textAvatarer{v, t}
+}
+
+func (w textAvatarer) Avatar() string {
+	r0 := w.v.Avatar()
+	return r0
+}
+
+func (w textAvatarer) AvatarSize() int {
+	r0 := w.v.AvatarSize()
+	return r0
+}
+
+func (w textAvatarer) AvatarText() string {
+	r0 := w.v.AvatarText()
+	return r0
+}
+
+type textColorer struct {
+	v text.Colorer
+	t *Checker
+}
+
+// TextColorer wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func TextColorer(v text.Colorer, t *Checker) text.Colorer {
+	if v == nil {
+		return nil
+	}
+	return textColorer{v, t}
+}
+
+func (w textColorer) Color() uint32 {
+	r0 := w.v.Color()
+	return r0
+}
+
+type textMentioner struct {
+	v text.Mentioner
+	t *Checker
+}
+
+// TextMentioner wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func TextMentioner(v text.Mentioner, t *Checker) text.Mentioner {
+	if v == nil {
+		return nil
+	}
+	return textMentioner{v, t}
+}
+
+func (w textMentioner) MentionInfo() text.Rich {
+	r0 := w.v.MentionInfo()
+	return r0
+}
+
+type textAttributor struct {
+	v text.Attributor
+	t *Checker
+}
+
+// TextAttributor wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func TextAttributor(v text.Attributor, t *Checker) text.Attributor {
+	if v == nil {
+		return nil
+	}
+	return textAttributor{v, t}
+}
+
+func (w textAttributor) Attribute() text.Attribute {
+	r0 := w.v.Attribute()
+	return r0
+}
+
+type textCodeblocker struct {
+	v text.Codeblocker
+	t *Checker
+}
+
+// TextCodeblocker wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func TextCodeblocker(v text.Codeblocker, t *Checker) text.Codeblocker {
+	if v == nil {
+		return nil
+	}
+	return textCodeblocker{v, t}
+}
+
+func (w textCodeblocker) CodeblockLanguage() string {
+	r0 := w.v.CodeblockLanguage()
+	return r0
+}
+
+type textQuoteblocker struct {
+	v text.Quoteblocker
+	t *Checker
+}
+
+// TextQuoteblocker wraps v to check the containers given to its methods with t.
+// Nil is returned if v is nil.
+func TextQuoteblocker(v text.Quoteblocker, t *Checker) text.Quoteblocker {
+	if v == nil {
+		return nil
+	}
+	return textQuoteblocker{v, t}
+}
+
+func (w textQuoteblocker) QuotePrefix() string {
+	r0 := w.v.QuotePrefix()
+	return r0
+}
+
+func authenticatorSlice(v []cchat.Authenticator, t *Checker) []cchat.Authenticator {
+	if v == nil {
+		return nil
+	}
+	w := make([]cchat.Authenticator, len(v))
+	for i := range v {
+		w[i] = Authenticator(v[i], t)
+	}
+	return w
+}
+
+func serverSlice(v []cchat.Server, t *Checker) []cchat.Server {
+	if v == nil {
+		return nil
+	}
+	w := make([]cchat.Server, len(v))
+	for i := range v {
+		w[i] = Server(v[i], t)
+	}
+	return w
+}
+
+func memberSectionSlice(v []cchat.MemberSection, t *Checker) []cchat.MemberSection {
+	if v == nil {
+		return nil
+	}
+	w := make([]cchat.MemberSection, len(v))
+	for i := range v {
+		w[i] = MemberSection(v[i], t)
+	}
+	return w
+}
@@ -0,0 +1,304 @@
+package contract
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/diamondburned/cchat"
+	"github.com/diamondburned/cchat/text"
+	"github.com/diamondburned/cchat/utils/empty"
+	"github.com/go-test/deep"
+)
+
+type recorder struct {
+	mu         sync.Mutex
+	violations []string
+}
+
+func (r *recorder) Handle(v Violation) {
+	r.mu.Lock()
+	r.violations = append(r.violations, v.Error())
+	r.mu.Unlock()
+}
+
+// testMessenger gives the MessagesContainer from JoinServer to the test.
+type testMessenger struct {
+	empty.Messenger
+	joined chan cchat.MessagesContainer
+}
+
+func (m testMessenger) JoinServer(ctx context.Context, c cchat.MessagesContainer) (func(), error) {
+	m.joined <- c
+	return func() {}, nil
+}
+
+type testMessage struct {
+	id     cchat.ID
+	author cchat.User
+}
+
+func (m testMessage) ID() cchat.ID           { return m.id }
+func (m testMessage) Time() time.Time        { return time.Time{} }
+func (m testMessage) Nonce() string          { return "" }
+func (m testMessage) Mentioned() bool        { return false }
+func (m testMessage) Content() text.Rich     { return text.Plain("hello") }
+func (m testMessage) Author() cchat.User     { return m.author }
+func (m testMessage) AsNoncer() cchat.Noncer { return nil }
+
+func (m testMessage) AsMassMentioner() cchat.MassMentioner { return nil }
+
+type testUser struct{}
+
+func (testUser) ID() cchat.ID { return "user" }
+
+func (testUser) Name(context.Context, cchat.LabelContainer) (func(), error) { return nil, nil }
+
+// testMessages records the IDs of calls that reach the frontend.
+type testMessages struct {
+	calls []string
+}
+
+func (m *testMessages) CreateMessage(_ context.Context, msg cchat.MessageCreate) {
+	m.calls = append(m.calls, "create "+msg.ID())
+}
+
+func (m *testMessages) UpdateMessage(_ context.Context, msg cchat.MessageUpdate) {
+	m.calls = append(m.calls, "update "+msg.ID())
+}
+
+func (m *testMessages) DeleteMessage(_ context.Context, msg cchat.MessageDelete) {
+	m.calls = append(m.calls, "delete "+msg.ID())
+}
+
+func TestMessages(t *testing.T) {
+	r := &recorder{}
+	backend := testMessenger{joined: make(chan cchat.MessagesContainer, 1)}
+	frontend := &testMessages{}
+
+	stop, err := Messenger(backend, New(r)).JoinServer(context.Background(), frontend)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	ctx := context.Background()
+	c := <-backend.joined
+
+	c.UpdateMessage(ctx, testMessage{id: "1"})
+	c.CreateMessage(ctx, testMessage{id: "1"})
+	c.CreateMessage(ctx, testMessage{id: "1", author: testUser{}})
+	c.UpdateMessage(ctx, testMessage{id: "1"})
+	c.DeleteMessage(ctx, testMessage{id: "1"})
+	c.DeleteMessage(ctx, testMessage{id: "1"})
+
+	stop()
+	c.CreateMessage(ctx, testMessage{id: "2", author: testUser{}})
+
+	expectCalls := []string{"create 1", "update 1", "delete 1"}
+	if diff := deep.Equal(expectCalls, frontend.calls); diff != nil {
+		t.Fatal("Unexpected frontend calls:", diff)
+	}
+
+	expectViolations := []string{
+		`cchat.MessagesContainer.UpdateMessage from cchat.Messenger.JoinServer: message "1" was never created`,
+		`cchat.MessagesContainer.CreateMessage from cchat.Messenger.JoinServer: message "1" has a nil Author`,
+		`cchat.MessagesContainer.DeleteMessage from cchat.Messenger.JoinServer: message "1" was never created`,
+		`cchat.MessagesContainer.CreateMessage from cchat.Messenger.JoinServer: called after being stopped`,
+	}
+	if diff := deep.Equal(expectViolations, r.violations); diff != nil {
+		t.Fatal("Unexpected violations:", diff)
+	}
+}
+
+// testPinMessenger gives the MessagesContainers from JoinServer, Backlog and
+// Pins to the test.
+type testPinMessenger struct {
+	testMessenger
+	pins    chan cchat.MessagesContainer
+	backlog func(cchat.MessagesContainer)
+}
+
+func (m testPinMessenger) AsPinner() cchat.Pinner         { return m }
+func (m testPinMessenger) AsBacklogger() cchat.Backlogger { return m }
+
+func (m testPinMessenger) Pins(ctx context.Context, c cchat.MessagesContainer) (func(), error) {
+	m.pins <- c
+	return func() {}, nil
+}
+
+func (testPinMessenger) Pin(context.Context, cchat.ID) error   { return nil }
+func (testPinMessenger) Unpin(context.Context, cchat.ID) error { return nil }
+func (testPinMessenger) IsPinnable(cchat.ID) bool              { return true }
+
+func (m testPinMessenger) Backlog(ctx context.Context, _ cchat.ID, c cchat.MessagesContainer) error {
+	m.backlog(c)
+	return nil
+}
+
+func TestMessageScopes(t *testing.T) {
+	r := &recorder{}
+	ctx := context.Background()
+
+	backend := testPinMessenger{
+		testMessenger: testMessenger{joined: make(chan cchat.MessagesContainer, 1)},
+		pins:          make(chan cchat.MessagesContainer, 1),
+		backlog: func(c cchat.MessagesContainer) {
+			c.CreateMessage(ctx, testMessage{id: "backlog", author: testUser{}})
+		},
+	}
+
+	messenger := Messenger(backend, New(r))
+
+	stop, _ := messenger.JoinServer(ctx, &testMessages{})
+	joined := <-backend.joined
+
+	messenger.AsPinner().Pins(ctx, &testMessages{})
+	pins := <-backend.pins
+
+	joined.CreateMessage(ctx, testMessage{id: "1", author: testUser{}})
+
+	// Unpinning a message doesn't delete it from JoinServer's container.
+	pins.CreateMessage(ctx, testMessage{id: "1", author: testUser{}})
+	pins.DeleteMessage(ctx, testMessage{id: "1"})
+	joined.UpdateMessage(ctx, testMessage{id: "1"})
+
+	// Messages from JoinServer are not in Pins' container.
+	joined.CreateMessage(ctx, testMessage{id: "2", author: testUser{}})
+	pins.UpdateMessage(ctx, testMessage{id: "2"})
+
+	// Messages from Backlog are in JoinServer's container.
+	if err := messenger.AsBacklogger().Backlog(ctx, "1", &testMessages{}); err != nil {
+		t.Fatal("Unexpected Backlog error:", err)
+	}
+	joined.UpdateMessage(ctx, testMessage{id: "backlog"})
+
+	// Message IDs are forgotten once JoinServer is stopped.
+	stop()
+
+	messenger.JoinServer(ctx, &testMessages{})
+	rejoined := <-backend.joined
+	rejoined.UpdateMessage(ctx, testMessage{id: "1"})
+
+	expect := []string{
+		`cchat.MessagesContainer.UpdateMessage from cchat.Pinner.Pins: message "2" was never created`,
+		`cchat.MessagesContainer.UpdateMessage from cchat.Messenger.JoinServer: message "1" was never created`,
+	}
+	if diff := deep.Equal(expect, r.violations); diff != nil {
+		t.Fatal("Unexpected violations:", diff)
+	}
+}
+
+type testMessengerServer struct {
+	empty.Server
+	messenger cchat.Messenger
+}
+
+func (testMessengerServer) ID() cchat.ID    { return "1" }
+func (testMessengerServer) Columnate() bool { return false }
+
+func (testMessengerServer) Name(context.Context, cchat.LabelContainer) (func(), error) {
+	return nil, nil
+}
+
+func (s testMessengerServer) AsMessenger() cchat.Messenger { return s.messenger }
+
+func TestMessengerServer(t *testing.T) {
+	r := &recorder{}
+	ctx := context.Background()
+
+	backend := testPinMessenger{
+		testMessenger: testMessenger{joined: make(chan cchat.MessagesContainer, 1)},
+		backlog: func(c cchat.MessagesContainer) {
+			c.CreateMessage(ctx, testMessage{id: "backlog", author: testUser{}})
+		},
+	}
+
+	server := Server(testMessengerServer{messenger: backend}, New(r))
+
+	stop, _ := server.AsMessenger().JoinServer(ctx, &testMessages{})
+	first := <-backend.joined
+
+	server.AsMessenger().JoinServer(ctx, &testMessages{})
+	second := <-backend.joined
+
+	// Messages from Backlog are in the containers of all joins of the server,
+	// even if the Messenger was asserted again.
+	if err := server.AsMessenger().AsBacklogger().Backlog(ctx, "1", &testMessages{}); err != nil {
+		t.Fatal("Unexpected Backlog error:", err)
+	}
+	first.UpdateMessage(ctx, testMessage{id: "backlog"})
+	second.UpdateMessage(ctx, testMessage{id: "backlog"})
+
+	// Stopping one join doesn't forget the IDs of the other.
+	second.CreateMessage(ctx, testMessage{id: "1", author: testUser{}})
+	stop()
+
+	second.UpdateMessage(ctx, testMessage{id: "1"})
+	second.UpdateMessage(ctx, testMessage{id: "backlog"})
+
+	if len(r.violations) > 0 {
+		t.Fatal("Unexpected violations:", r.violations)
+	}
+}
+
+type testLister struct {
+	empty.Server
+}
+
+func (testLister) ID() cchat.ID { return "1" }
+
+func (testLister) Name(ctx context.Context, l cchat.LabelContainer) (func(), error) {
+	// Out of bounds.
+	l.SetLabel(ctx, text.Rich{
+		Content:  "general",
+		Segments: []text.Segment{testSegment{start: 0, end: 8}},
+	})
+	return nil, nil
+}
+
+func (testLister) Columnate() bool { return false }
+
+func (s testLister) Servers(c cchat.ServersContainer) (func(), error) {
+	c.UpdateServer(context.Background(), nil)
+	c.SetServers(context.Background(), []cchat.Server{s})
+	return nil, nil
+}
+
+type testSegment struct {
+	empty.TextSegment
+	start, end int
+}
+
+func (s testSegment) Bounds() (int, int) { return s.start, s.end }
+
+type testServers struct{}
+
+func (testServers) SetServers(context.Context, []cchat.Server)       {}
+func (testServers) UpdateServer(context.Context, cchat.ServerUpdate) {}
+
+type testLabel struct{}
+
+func (testLabel) SetLabel(context.Context, text.Rich) {}
+
+func TestServers(t *testing.T) {
+	r := &recorder{}
+	c := New(r)
+
+	if _, err := Lister(testLister{}, c).Servers(testServers{}); err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	if _, err := Server(testLister{}, c).Name(context.Background(), testLabel{}); err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	expect := []string{
+		"cchat.ServersContainer.UpdateServer from cchat.Lister.Servers: called before SetServers",
+		"cchat.LabelContainer.SetLabel from cchat.Namer.Name: " +
+			"segment 0 has bounds [0, 8) outside content of length 7",
+	}
+	if diff := deep.Equal(expect, r.violations); diff != nil {
+		t.Fatal("Unexpected violations:", diff)
+	}
+}
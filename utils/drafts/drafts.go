@@ -0,0 +1,187 @@
+// Package drafts provides a persistent store for frontends to keep the unsent
+// input of each server, so that switching servers or restarting does not
+// discard what was being typed.
+//
+// Drafts are stored locally as JSON files keyed by the service, session and
+// server IDs. For servers whose Messenger implements DraftSyncer, Pull and
+// Push can be used to synchronize the draft's content and reply target with
+// other devices; attachments are always local.
+package drafts
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/diamondburned/cchat"
+	"github.com/pkg/errors"
+)
+
+// Key identifies the draft of a single server.
+type Key struct {
+	ServiceID cchat.ID
+	SessionID cchat.ID
+	ServerID  cchat.ID
+}
+
+// path returns the path of the draft file relative to the store. IDs are
+// escaped, so they can contain any character.
+func (k Key) path() string {
+	return filepath.Join(
+		escape(k.ServiceID),
+		escape(k.SessionID),
+		escape(k.ServerID)+".json",
+	)
+}
+
+func escape(id cchat.ID) string {
+	// PathEscape does not escape dots, so escape "." and ".." manually to not
+	// walk out of the store.
+	if id == "." || id == ".." {
+		return strings.Replace(id, ".", "%2E", -1)
+	}
+	return url.PathEscape(id)
+}
+
+// Draft is the unsent input of a server.
+type Draft struct {
+	Content     string
+	ReplyingTo  cchat.ID     `json:",omitempty"`
+	Attachments []Attachment `json:",omitempty"`
+}
+
+// Attachment is a pending attachment of a draft. Frontends should save
+// attachments that are not files, such as pasted images, to a file first.
+type Attachment struct {
+	Name     string
+	Path     string
+	MIMEType string `json:",omitempty"`
+}
+
+// IsEmpty returns true if the draft has no content, reply target or
+// attachments.
+func (d Draft) IsEmpty() bool {
+	return d.Content == "" && d.ReplyingTo == "" && len(d.Attachments) == 0
+}
+
+// Store is a directory of drafts. A Store is safe to use concurrently as long
+// as the same key is not saved concurrently.
+type Store struct {
+	dir string
+}
+
+// NewStore creates a new store in the given directory, creating it if it does
+// not exist.
+func NewStore(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, errors.Wrap(err, "Failed to make drafts dir")
+	}
+	return &Store{dir}, nil
+}
+
+// Load loads the draft with the given key. An empty draft is returned if there
+// is none.
+func (s *Store) Load(key Key) (Draft, error) {
+	var draft Draft
+
+	b, err := ioutil.ReadFile(filepath.Join(s.dir, key.path()))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return draft, nil
+		}
+		return draft, errors.Wrap(err, "Failed to read draft")
+	}
+
+	if err := json.Unmarshal(b, &draft); err != nil {
+		return draft, errors.Wrap(err, "Failed to decode draft")
+	}
+
+	return draft, nil
+}
+
+// Save saves the draft with the given key. Saving an empty draft deletes it.
+func (s *Store) Save(key Key, draft Draft) error {
+	if draft.IsEmpty() {
+		return s.Delete(key)
+	}
+
+	path := filepath.Join(s.dir, key.path())
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return errors.Wrap(err, "Failed to make draft dir")
+	}
+
+	b, err := json.Marshal(draft)
+	if err != nil {
+		return errors.Wrap(err, "Failed to encode draft")
+	}
+
+	// Write to a temporary file and rename it, so that a crash never leaves a
+	// partially written draft behind.
+	if err := ioutil.WriteFile(path+".tmp", b, 0600); err != nil {
+		return errors.Wrap(err, "Failed to write draft")
+	}
+
+	if err := os.Rename(path+".tmp", path); err != nil {
+		return errors.Wrap(err, "Failed to write draft")
+	}
+
+	return nil
+}
+
+// Delete deletes the draft with the given key. Deleting a draft that does not
+// exist is not an error.
+func (s *Store) Delete(key Key) error {
+	err := os.Remove(filepath.Join(s.dir, key.path()))
+	if err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "Failed to delete draft")
+	}
+	return nil
+}
+
+// Pull fetches the draft from the syncer and merges it into the local draft:
+// the content and reply target are replaced by the synced ones, while the
+// local attachments are kept. The local draft is returned as-is if the synced
+// draft is empty. The merged draft is saved and returned.
+func (s *Store) Pull(ctx context.Context, key Key, syncer cchat.DraftSyncer) (Draft, error) {
+	local, err := s.Load(key)
+	if err != nil {
+		return local, err
+	}
+
+	synced, err := syncer.Draft(ctx)
+	if err != nil {
+		return local, errors.Wrap(err, "Failed to get synced draft")
+	}
+
+	if synced == (cchat.Draft{}) {
+		return local, nil
+	}
+
+	local.Content = synced.Content
+	local.ReplyingTo = synced.ReplyingTo
+
+	return local, s.Save(key, local)
+}
+
+// Push saves the draft locally and then sets it on the syncer. The local draft
+// is saved even if syncing fails.
+func (s *Store) Push(ctx context.Context, key Key, syncer cchat.DraftSyncer, draft Draft) error {
+	if err := s.Save(key, draft); err != nil {
+		return err
+	}
+
+	err := syncer.SetDraft(ctx, cchat.Draft{
+		Content:    draft.Content,
+		ReplyingTo: draft.ReplyingTo,
+	})
+	if err != nil {
+		return errors.Wrap(err, "Failed to set synced draft")
+	}
+
+	return nil
+}
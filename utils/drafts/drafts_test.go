@@ -0,0 +1,109 @@
+package drafts
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/diamondburned/cchat"
+	"github.com/go-test/deep"
+)
+
+type syncer struct{ draft cchat.Draft }
+
+func (s *syncer) Draft(context.Context) (cchat.Draft, error) { return s.draft, nil }
+
+func (s *syncer) SetDraft(_ context.Context, draft cchat.Draft) error {
+	s.draft = draft
+	return nil
+}
+
+func newStore(t *testing.T) (*Store, func()) {
+	dir, err := ioutil.TempDir("", "cchat-drafts-")
+	if err != nil {
+		t.Fatal("Failed to make temp dir:", err)
+	}
+
+	s, err := NewStore(dir)
+	if err != nil {
+		os.RemoveAll(dir)
+		t.Fatal("Failed to create store:", err)
+	}
+
+	return s, func() { os.RemoveAll(dir) }
+}
+
+func TestStore(t *testing.T) {
+	s, cleanup := newStore(t)
+	defer cleanup()
+
+	key := Key{"com.example.service", "user/1", "server 1"}
+	draft := Draft{
+		Content:     "hello",
+		ReplyingTo:  "123",
+		Attachments: []Attachment{{Name: "a.png", Path: "/tmp/a.png"}},
+	}
+
+	if err := s.Save(key, draft); err != nil {
+		t.Fatal("Failed to save:", err)
+	}
+
+	loaded, err := s.Load(key)
+	if err != nil {
+		t.Fatal("Failed to load:", err)
+	}
+	if eq := deep.Equal(loaded, draft); eq != nil {
+		t.Fatal("Unexpected loaded draft:", eq)
+	}
+
+	// Other servers should not be affected.
+	if other, _ := s.Load(Key{key.ServiceID, key.SessionID, "server 2"}); !other.IsEmpty() {
+		t.Fatal("Unexpected draft for another server:", other)
+	}
+
+	// Saving an empty draft deletes it.
+	if err := s.Save(key, Draft{}); err != nil {
+		t.Fatal("Failed to save empty draft:", err)
+	}
+	if loaded, _ := s.Load(key); !loaded.IsEmpty() {
+		t.Fatal("Empty draft was not deleted:", loaded)
+	}
+}
+
+func TestSync(t *testing.T) {
+	s, cleanup := newStore(t)
+	defer cleanup()
+
+	key := Key{"service", "session", "server"}
+	local := Draft{
+		Content:     "local",
+		Attachments: []Attachment{{Name: "a.png", Path: "/tmp/a.png"}},
+	}
+
+	sync := &syncer{}
+
+	if err := s.Push(context.Background(), key, sync, local); err != nil {
+		t.Fatal("Failed to push:", err)
+	}
+	if sync.draft.Content != "local" {
+		t.Fatal("Unexpected synced content:", sync.draft.Content)
+	}
+
+	// Another device changes the draft.
+	sync.draft = cchat.Draft{Content: "remote", ReplyingTo: "1"}
+
+	pulled, err := s.Pull(context.Background(), key, sync)
+	if err != nil {
+		t.Fatal("Failed to pull:", err)
+	}
+
+	expect := Draft{
+		Content:     "remote",
+		ReplyingTo:  "1",
+		Attachments: local.Attachments,
+	}
+	if eq := deep.Equal(pulled, expect); eq != nil {
+		t.Fatal("Unexpected pulled draft:", eq)
+	}
+}
@@ -0,0 +1,118 @@
+// Package emoji provides a shared ":shortcode" emoji completer for backends
+// that implement Emojier. Using it keeps the completion trigger and entries
+// consistent across backends.
+package emoji
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/diamondburned/cchat"
+	"github.com/diamondburned/cchat/text"
+)
+
+// Prefix is the prefix that triggers emoji completion.
+const Prefix = ":"
+
+// MinLength is the minimum length of the shortcode after the prefix before the
+// completer returns any entries.
+const MinLength = 2
+
+// MaxEntries is the default maximum number of completion entries.
+const MaxEntries = 25
+
+// Completer is a cchat.Completer that completes emoji shortcodes from a list
+// of emoji groups. The backend should fetch the emojis asynchronously and call
+// SetGroups, as Complete must not do IO. A Completer is thread-safe.
+type Completer struct {
+	// Limit is the maximum number of entries returned. It defaults to
+	// MaxEntries if it's zero.
+	Limit int
+
+	mutex  sync.RWMutex
+	groups []cchat.EmojiGroup
+}
+
+var _ cchat.Completer = (*Completer)(nil)
+
+// NewCompleter creates a new completer with the given groups.
+func NewCompleter(groups []cchat.EmojiGroup) *Completer {
+	return &Completer{groups: groups}
+}
+
+// SetGroups replaces the emoji groups.
+func (c *Completer) SetGroups(groups []cchat.EmojiGroup) {
+	c.mutex.Lock()
+	c.groups = groups
+	c.mutex.Unlock()
+}
+
+// Complete returns the completion entries for the current word if it starts
+// with Prefix. Emojis whose shortcode starts with the word come before those
+// that only contain it. Stickers are never completed.
+func (c *Completer) Complete(words []string, current int64) []cchat.CompletionEntry {
+	if current < 0 || current >= int64(len(words)) {
+		return nil
+	}
+
+	word := words[current]
+	if !strings.HasPrefix(word, Prefix) {
+		return nil
+	}
+
+	query := strings.ToLower(strings.TrimPrefix(word, Prefix))
+	if len(query) < MinLength {
+		return nil
+	}
+
+	var limit = c.Limit
+	if limit <= 0 {
+		limit = MaxEntries
+	}
+
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	var prefixed, contained []cchat.CompletionEntry
+
+	for _, group := range c.groups {
+		for _, emoji := range group.Emojis {
+			if emoji.Sticker {
+				continue
+			}
+
+			shortcode := strings.ToLower(emoji.Shortcode)
+
+			switch {
+			case strings.HasPrefix(shortcode, query):
+				prefixed = append(prefixed, Entry(group, emoji))
+				if len(prefixed) >= limit {
+					return prefixed
+				}
+			case len(contained) < limit && strings.Contains(shortcode, query):
+				contained = append(contained, Entry(group, emoji))
+			}
+		}
+	}
+
+	entries := append(prefixed, contained...)
+	if len(entries) > limit {
+		entries = entries[:limit]
+	}
+
+	return entries
+}
+
+// Entry creates a completion entry for the given emoji in the given group.
+// The raw text is the shortcode surrounded by colons.
+func Entry(group cchat.EmojiGroup, emoji cchat.Emoji) cchat.CompletionEntry {
+	raw := Prefix + emoji.Shortcode + Prefix
+
+	return cchat.CompletionEntry{
+		Raw:       raw,
+		Text:      text.Plain(raw),
+		Secondary: text.Plain(group.Name),
+		IconURL:   emoji.ImageURL,
+		Image:     true,
+	}
+}
@@ -0,0 +1,84 @@
+package emoji
+
+import (
+	"testing"
+
+	"github.com/diamondburned/cchat"
+)
+
+var groups = []cchat.EmojiGroup{{
+	Name: "Guild",
+	Emojis: []cchat.Emoji{
+		{Name: "thonk", Shortcode: "thonk", ImageURL: "https://example.com/thonk.png"},
+		{Name: "bigthink", Shortcode: "BigThink", ImageURL: "https://example.com/big.png"},
+		{Name: "thinksticker", Shortcode: "thinksticker", Sticker: true},
+	},
+}, {
+	Name: "People",
+	Emojis: []cchat.Emoji{
+		{Name: "🤔", Shortcode: "thinking"},
+	},
+}}
+
+func raws(entries []cchat.CompletionEntry) []string {
+	var raws []string
+	for _, entry := range entries {
+		raws = append(raws, entry.Raw)
+	}
+	return raws
+}
+
+func TestCompleter(t *testing.T) {
+	type test struct {
+		words   []string
+		current int64
+		output  []string
+	}
+
+	var tests = []test{
+		{[]string{"hi", ":th"}, 1, []string{":thonk:", ":thinking:", ":BigThink:"}},
+		{[]string{":thi"}, 0, []string{":thinking:", ":BigThink:"}},
+		{[]string{":t"}, 0, nil},
+		{[]string{"thi"}, 0, nil},
+		{[]string{":thi"}, 1, nil},
+	}
+
+	c := NewCompleter(groups)
+
+	for _, test := range tests {
+		output := raws(c.Complete(test.words, test.current))
+		if !strsleq(output, test.output) {
+			t.Errorf("Unexpected output for %q (got/expected): %q %q",
+				test.words, output, test.output)
+		}
+	}
+
+	c.Limit = 1
+
+	if output := raws(c.Complete([]string{":th"}, 0)); !strsleq(output, []string{":thonk:"}) {
+		t.Errorf("Unexpected output with limit: %q", output)
+	}
+}
+
+func TestEntry(t *testing.T) {
+	entry := Entry(groups[0], groups[0].Emojis[0])
+
+	if entry.IconURL != "https://example.com/thonk.png" {
+		t.Errorf("Unexpected icon URL %q", entry.IconURL)
+	}
+	if entry.Secondary.Content != "Guild" {
+		t.Errorf("Unexpected secondary text %q", entry.Secondary.Content)
+	}
+}
+
+func strsleq(s1, s2 []string) bool {
+	if len(s1) != len(s2) {
+		return false
+	}
+	for i := range s1 {
+		if s1[i] != s2[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -27,6 +27,21 @@ func (Session) AsCommander() cchat.Commander { return nil }
 // AsSessionSaver returns nil.
 func (Session) AsSessionSaver() cchat.SessionSaver { return nil }
 
+// AsPresenceSetter returns nil.
+func (Session) AsPresenceSetter() cchat.PresenceSetter { return nil }
+
+// AsProfiler returns nil.
+func (Session) AsProfiler() cchat.Profiler { return nil }
+
+// AsDirectMessager returns nil.
+func (Session) AsDirectMessager() cchat.DirectMessager { return nil }
+
+// AsEmojier returns nil.
+func (Session) AsEmojier() cchat.Emojier { return nil }
+
+// AsConnectionStater returns nil.
+func (Session) AsConnectionStater() cchat.ConnectionStater { return nil }
+
 // Commander provides no-op asserters for cchat.Commander.
 type Commander struct{}
 
@@ -48,6 +63,9 @@ func (Server) AsCommander() cchat.Commander { return nil }
 // AsConfigurator returns nil.
 func (Server) AsConfigurator() cchat.Configurator { return nil }
 
+// AsNotificationSettings returns nil.
+func (Server) AsNotificationSettings() cchat.NotificationSettings { return nil }
+
 // Messenger provides no-op asserters for cchat.Messenger.
 type Messenger struct{}
 
@@ -57,6 +75,9 @@ func (Messenger) AsSender() cchat.Sender { return nil }
 // AsEditor returns nil.
 func (Messenger) AsEditor() cchat.Editor { return nil }
 
+// AsDeleter returns nil.
+func (Messenger) AsDeleter() cchat.Deleter { return nil }
+
 // AsActioner returns nil.
 func (Messenger) AsActioner() cchat.Actioner { return nil }
 
@@ -69,18 +90,60 @@ func (Messenger) AsBacklogger() cchat.Backlogger { return nil }
 // AsMemberLister returns nil.
 func (Messenger) AsMemberLister() cchat.MemberLister { return nil }
 
+// AsReadIndicator returns nil.
+func (Messenger) AsReadIndicator() cchat.ReadIndicator { return nil }
+
 // AsUnreadIndicator returns nil.
 func (Messenger) AsUnreadIndicator() cchat.UnreadIndicator { return nil }
 
 // AsTypingIndicator returns nil.
 func (Messenger) AsTypingIndicator() cchat.TypingIndicator { return nil }
 
+// AsProfiler returns nil.
+func (Messenger) AsProfiler() cchat.Profiler { return nil }
+
+// AsPinner returns nil.
+func (Messenger) AsPinner() cchat.Pinner { return nil }
+
+// AsEmojier returns nil.
+func (Messenger) AsEmojier() cchat.Emojier { return nil }
+
+// AsDraftSyncer returns nil.
+func (Messenger) AsDraftSyncer() cchat.DraftSyncer { return nil }
+
 // Sender provides no-op asserters for cchat.Sender.
 type Sender struct{}
 
 // AsCompleter returns nil.
 func (Sender) AsCompleter() cchat.Completer { return nil }
 
+// AsSendStateIndicator returns nil.
+func (Sender) AsSendStateIndicator() cchat.SendStateIndicator { return nil }
+
+// Actioner provides no-op asserters for cchat.Actioner.
+type Actioner struct{}
+
+// AsActionDescriber returns nil.
+func (Actioner) AsActionDescriber() cchat.ActionDescriber { return nil }
+
+// MessageCreate provides no-op asserters for cchat.MessageCreate.
+type MessageCreate struct{}
+
+// AsMassMentioner returns nil.
+func (MessageCreate) AsMassMentioner() cchat.MassMentioner { return nil }
+
+// UnreadContainer provides no-op asserters for cchat.UnreadContainer.
+type UnreadContainer struct{}
+
+// AsUnreadCountContainer returns nil.
+func (UnreadContainer) AsUnreadCountContainer() cchat.UnreadCountContainer { return nil }
+
+// ListMember provides no-op asserters for cchat.ListMember.
+type ListMember struct{}
+
+// AsDirectMessager returns nil.
+func (ListMember) AsDirectMessager() cchat.DirectMessager { return nil }
+
 // MemberSection provides no-op asserters for cchat.MemberSection.
 type MemberSection struct{}
 
@@ -99,6 +162,12 @@ func (SendableMessage) AsReplier() cchat.Replier { return nil }
 // AsAttacher returns nil.
 func (SendableMessage) AsAttacher() cchat.Attacher { return nil }
 
+// Attacher provides no-op asserters for cchat.Attacher.
+type Attacher struct{}
+
+// AsUploadProgressContainer returns nil.
+func (Attacher) AsUploadProgressContainer() cchat.UploadProgressContainer { return nil }
+
 // TextSegment provides no-op asserters for cchat.TextSegment.
 type TextSegment struct{}
 
@@ -0,0 +1,214 @@
+// Package notify provides a notification policy layer for frontends. It wraps
+// the MessagesContainer and UnreadContainer given to the backend, applies each
+// server's NotificationSettings and emits desktop-agnostic Notification
+// values that the frontend can display however it wants.
+package notify
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/diamondburned/cchat"
+)
+
+// Notification is a single notification that should be shown to the user.
+type Notification struct {
+	Server cchat.Server
+	// Message is the message that triggered the notification. It is nil if
+	// the notification is from an unread event of a server that is not
+	// joined.
+	Message cchat.MessageCreate
+	// Mentioned is true if the notification is for a mention.
+	Mentioned bool
+}
+
+// Dispatcher decides which messages and unread events should notify the user.
+// A Dispatcher should be created for each session.
+type Dispatcher struct {
+	// DefaultLevel is the level used for servers that don't implement
+	// NotificationSettings or have the default level. A zero value is treated
+	// as NotificationLevelAll.
+	DefaultLevel cchat.NotificationLevel
+
+	notify func(Notification)
+	selfID cchat.ID
+	now    func() time.Time
+
+	mutex  sync.Mutex
+	joined map[cchat.ID]int
+}
+
+// NewDispatcher creates a new dispatcher for the session with the given user
+// ID. Messages sent by that user never notify. The given callback is called
+// from the backend's goroutine, so it must not block.
+func NewDispatcher(selfID cchat.ID, notify func(Notification)) *Dispatcher {
+	return &Dispatcher{
+		notify: notify,
+		selfID: selfID,
+		now:    time.Now,
+		joined: map[cchat.ID]int{},
+	}
+}
+
+// ShouldNotify returns true if a message in the given server should notify the
+// user according to the server's notification preference.
+func (d *Dispatcher) ShouldNotify(server cchat.Server, mentioned bool) bool {
+	pref := preference(server)
+
+	if pref.MutedUntil.After(d.now()) {
+		return false
+	}
+
+	var level = pref.Level
+	if level == cchat.NotificationLevelDefault {
+		level = d.DefaultLevel
+	}
+
+	switch level {
+	case cchat.NotificationLevelNone:
+		return false
+	case cchat.NotificationLevelMentions:
+		return mentioned
+	default:
+		return true
+	}
+}
+
+// Messages wraps the given MessagesContainer of a joined server to notify on
+// new messages. The returned function must be called when the stop callback
+// of JoinServer is called. While a server is joined, its unread events do not
+// notify, since the messages already do. Messages that implement MassMentioner don't count as
+// mentions if the server's preference has SuppressEveryone.
+func (d *Dispatcher) Messages(
+	server cchat.Server, msgc cchat.MessagesContainer) (cchat.MessagesContainer, func()) {
+
+	id := server.ID()
+
+	d.mutex.Lock()
+	d.joined[id]++
+	d.mutex.Unlock()
+
+	var once sync.Once
+	leave := func() {
+		once.Do(func() {
+			d.mutex.Lock()
+			defer d.mutex.Unlock()
+
+			if d.joined[id]--; d.joined[id] <= 0 {
+				delete(d.joined, id)
+			}
+		})
+	}
+
+	return messagesContainer{msgc, d, server}, leave
+}
+
+// Unread wraps the given UnreadContainer of a server to notify when the server
+// becomes unread or mentioned.
+func (d *Dispatcher) Unread(server cchat.Server, uc cchat.UnreadContainer) cchat.UnreadContainer {
+	return &unreadContainer{UnreadContainer: uc, d: d, server: server}
+}
+
+func (d *Dispatcher) isJoined(id cchat.ID) bool {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.joined[id] > 0
+}
+
+func preference(server cchat.Server) cchat.NotificationPreference {
+	if settings := server.AsNotificationSettings(); settings != nil {
+		return settings.NotificationPreference()
+	}
+	return cchat.NotificationPreference{}
+}
+
+type messagesContainer struct {
+	cchat.MessagesContainer
+	d      *Dispatcher
+	server cchat.Server
+}
+
+func (c messagesContainer) CreateMessage(ctx context.Context, msg cchat.MessageCreate) {
+	c.MessagesContainer.CreateMessage(ctx, msg)
+
+	// Don't notify on our own messages.
+	if author := msg.Author(); author != nil && author.ID() == c.d.selfID {
+		return
+	}
+
+	mentioned := msg.Mentioned()
+
+	// Mentions of everyone don't count if the server suppresses them.
+	if mentioned && preference(c.server).SuppressEveryone {
+		if mm := msg.AsMassMentioner(); mm != nil && mm.MassMentioned() {
+			mentioned = false
+		}
+	}
+
+	if c.d.ShouldNotify(c.server, mentioned) {
+		c.d.notify(Notification{
+			Server:    c.server,
+			Message:   msg,
+			Mentioned: mentioned,
+		})
+	}
+}
+
+type unreadContainer struct {
+	cchat.UnreadContainer
+	d      *Dispatcher
+	server cchat.Server
+
+	mutex     sync.Mutex
+	unread    bool
+	mentioned bool
+}
+
+func (c *unreadContainer) SetUnread(ctx context.Context, unread, mentioned bool) {
+	c.UnreadContainer.SetUnread(ctx, unread, mentioned)
+	c.update(unread, mentioned)
+}
+
+// AsUnreadCountContainer wraps the frontend's UnreadCountContainer, if any, so
+// that count updates go through the same policy as SetUnread.
+func (c *unreadContainer) AsUnreadCountContainer() cchat.UnreadCountContainer {
+	ucc := c.UnreadContainer.AsUnreadCountContainer()
+	if ucc == nil {
+		return nil
+	}
+	return unreadCountContainer{ucc, c}
+}
+
+// update notifies if the unread state changed.
+func (c *unreadContainer) update(unread, mentioned bool) {
+	c.mutex.Lock()
+	// Only notify when the state changes from read to unread or from
+	// unmentioned to mentioned, as the backend may call SetUnread repeatedly.
+	changed := (unread && !c.unread) || (mentioned && !c.mentioned)
+	c.unread = unread
+	c.mentioned = mentioned
+	c.mutex.Unlock()
+
+	if !changed || c.d.isJoined(c.server.ID()) {
+		return
+	}
+
+	if c.d.ShouldNotify(c.server, mentioned) {
+		c.d.notify(Notification{
+			Server:    c.server,
+			Mentioned: mentioned,
+		})
+	}
+}
+
+type unreadCountContainer struct {
+	cchat.UnreadCountContainer
+	parent *unreadContainer
+}
+
+func (c unreadCountContainer) SetUnreadCount(ctx context.Context, unread, mentions int) {
+	c.UnreadCountContainer.SetUnreadCount(ctx, unread, mentions)
+	c.parent.update(unread > 0, mentions > 0)
+}
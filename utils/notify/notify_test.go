@@ -0,0 +1,217 @@
+package notify
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/diamondburned/cchat"
+	"github.com/diamondburned/cchat/text"
+	"github.com/diamondburned/cchat/utils/empty"
+)
+
+type server struct {
+	empty.Server
+	id   cchat.ID
+	pref cchat.NotificationPreference
+}
+
+func (s *server) ID() cchat.ID { return s.id }
+
+func (s *server) Name(context.Context, cchat.LabelContainer) (func(), error) {
+	return func() {}, nil
+}
+
+func (s *server) AsNotificationSettings() cchat.NotificationSettings { return s }
+
+func (s *server) NotificationPreference() cchat.NotificationPreference { return s.pref }
+
+func (s *server) SetNotificationPreference(_ context.Context, pref cchat.NotificationPreference) error {
+	s.pref = pref
+	return nil
+}
+
+type user cchat.ID
+
+func (u user) ID() cchat.ID { return cchat.ID(u) }
+
+func (u user) Name(context.Context, cchat.LabelContainer) (func(), error) {
+	return func() {}, nil
+}
+
+type message struct {
+	author    cchat.ID
+	mentioned bool
+}
+
+func (m message) ID() cchat.ID       { return "" }
+func (m message) Time() time.Time    { return time.Time{} }
+func (m message) Nonce() string      { return "" }
+func (m message) Author() cchat.User { return user(m.author) }
+func (m message) Content() text.Rich { return text.Rich{} }
+func (m message) Mentioned() bool    { return m.mentioned }
+
+func (m message) AsMassMentioner() cchat.MassMentioner { return nil }
+
+// massMessage mentions the user only by mentioning everyone.
+type massMessage struct{ message }
+
+func (m massMessage) AsMassMentioner() cchat.MassMentioner { return m }
+func (m massMessage) MassMentioned() bool                  { return true }
+
+type nopMessages struct{ cchat.MessagesContainer }
+
+func (nopMessages) CreateMessage(context.Context, cchat.MessageCreate) {}
+
+type nopUnread struct{ empty.UnreadContainer }
+
+func (nopUnread) SetUnread(context.Context, bool, bool) {}
+
+func TestDispatcherMessages(t *testing.T) {
+	var notified []Notification
+	d := NewDispatcher("self", func(n Notification) { notified = append(notified, n) })
+
+	s := &server{id: "1"}
+	msgc, leave := d.Messages(s, nopMessages{})
+	defer leave()
+
+	var tests = []struct {
+		name   string
+		pref   cchat.NotificationPreference
+		msg    cchat.MessageCreate
+		notify bool
+	}{
+		{"default", cchat.NotificationPreference{}, message{"a", false}, true},
+		{"self", cchat.NotificationPreference{}, message{"self", true}, false},
+		{
+			"mentions only",
+			cchat.NotificationPreference{Level: cchat.NotificationLevelMentions},
+			message{"a", false}, false,
+		},
+		{
+			"mentioned",
+			cchat.NotificationPreference{Level: cchat.NotificationLevelMentions},
+			message{"a", true}, true,
+		},
+		{
+			"everyone",
+			cchat.NotificationPreference{Level: cchat.NotificationLevelMentions},
+			massMessage{message{"a", true}}, true,
+		},
+		{
+			"everyone suppressed",
+			cchat.NotificationPreference{
+				Level:            cchat.NotificationLevelMentions,
+				SuppressEveryone: true,
+			},
+			massMessage{message{"a", true}}, false,
+		},
+		{
+			"none",
+			cchat.NotificationPreference{Level: cchat.NotificationLevelNone},
+			message{"a", true}, false,
+		},
+		{
+			"muted",
+			cchat.NotificationPreference{MutedUntil: time.Now().Add(time.Hour)},
+			message{"a", true}, false,
+		},
+		{
+			"mute expired",
+			cchat.NotificationPreference{MutedUntil: time.Now().Add(-time.Hour)},
+			message{"a", false}, true,
+		},
+	}
+
+	for _, test := range tests {
+		notified = nil
+		s.pref = test.pref
+		msgc.CreateMessage(context.Background(), test.msg)
+
+		if notify := len(notified) > 0; notify != test.notify {
+			t.Errorf("%s: expected notify %v, got %v", test.name, test.notify, notify)
+		}
+	}
+}
+
+func TestDispatcherUnread(t *testing.T) {
+	var notified []Notification
+	d := NewDispatcher("self", func(n Notification) { notified = append(notified, n) })
+
+	s := &server{id: "1"}
+	uc := d.Unread(s, nopUnread{})
+
+	uc.SetUnread(context.Background(), true, false)
+	uc.SetUnread(context.Background(), true, false)
+	uc.SetUnread(context.Background(), true, true)
+
+	if len(notified) != 2 {
+		t.Fatalf("Expected 2 notifications, got %d", len(notified))
+	}
+	if !notified[1].Mentioned || notified[1].Message != nil {
+		t.Fatalf("Unexpected mention notification: %#v", notified[1])
+	}
+
+	// Joined servers should not notify from unread events.
+	notified = nil
+	_, leave := d.Messages(s, nopMessages{})
+
+	uc.SetUnread(context.Background(), false, false)
+	uc.SetUnread(context.Background(), true, true)
+
+	if len(notified) != 0 {
+		t.Fatalf("Unexpected notifications while joined: %d", len(notified))
+	}
+
+	leave()
+
+	uc.SetUnread(context.Background(), false, false)
+	uc.SetUnread(context.Background(), true, false)
+
+	if len(notified) != 1 {
+		t.Fatalf("Expected 1 notification after leaving, got %d", len(notified))
+	}
+}
+
+type countUnread struct {
+	nopUnread
+	counts [][2]int
+}
+
+func (c *countUnread) AsUnreadCountContainer() cchat.UnreadCountContainer { return c }
+
+func (c *countUnread) SetUnreadCount(_ context.Context, unread, mentions int) {
+	c.counts = append(c.counts, [2]int{unread, mentions})
+}
+
+func TestDispatcherUnreadCount(t *testing.T) {
+	var notified []Notification
+	d := NewDispatcher("self", func(n Notification) { notified = append(notified, n) })
+
+	s := &server{id: "1", pref: cchat.NotificationPreference{
+		Level: cchat.NotificationLevelMentions,
+	}}
+
+	frontend := &countUnread{}
+	ucc := d.Unread(s, frontend).AsUnreadCountContainer()
+	if ucc == nil {
+		t.Fatal("Missing UnreadCountContainer.")
+	}
+
+	ucc.SetUnreadCount(context.Background(), 3, 0)
+	ucc.SetUnreadCount(context.Background(), 4, 1)
+	ucc.SetUnreadCount(context.Background(), 5, 2)
+
+	if len(frontend.counts) != 3 {
+		t.Fatalf("Expected 3 counts to reach the frontend, got %d", len(frontend.counts))
+	}
+
+	// Only the first mention notifies with the mentions level.
+	if len(notified) != 1 || !notified[0].Mentioned {
+		t.Fatalf("Unexpected notifications: %#v", notified)
+	}
+
+	if d.Unread(s, nopUnread{}).AsUnreadCountContainer() != nil {
+		t.Fatal("Unexpected UnreadCountContainer for a frontend without one.")
+	}
+}
@@ -0,0 +1,631 @@
+// Package outbox provides a cchat.Sender wrapper that persists outgoing
+// messages to disk before sending them, so that messages are not lost on
+// flaky connections or when the program exits.
+//
+// Every message given to Send is written to the outbox directory first,
+// including its attachments, and it is only removed once the wrapped Sender
+// has sent it. Messages that fail to send stay in the outbox and are sent in
+// order on the next Flush, which the frontend should call when the session
+// reconnects, as well as on startup to send messages left over from a previous
+// run. For sessions that implement ConnectionStater, the Outbox can be
+// subscribed as a ConnectionStateContainer to flush automatically on
+// reconnection.
+//
+// A message that keeps failing is marked as failed after MaxAttempts flushes,
+// or right away if IsPermanent says so, and later messages are sent without
+// it. Failed messages stay in the outbox until they are retried or removed.
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/diamondburned/cchat"
+	"github.com/pkg/errors"
+)
+
+const messageFile = "message.json"
+
+// DefaultMaxAttempts is the default value of Outbox's MaxAttempts.
+const DefaultMaxAttempts = 10
+
+// DefaultFlushTimeout is the default value of Outbox's FlushTimeout.
+const DefaultFlushTimeout = 5 * time.Minute
+
+// Entry is a persisted message in the outbox.
+type Entry struct {
+	Nonce       string
+	Content     string
+	ReplyingTo  cchat.ID          `json:",omitempty"`
+	Attachments []AttachmentEntry `json:",omitempty"`
+	// Attempts is the number of failed attempts to send the message.
+	Attempts int `json:",omitempty"`
+	// Failed is true if the message is not sent anymore until it's retried.
+	Failed bool `json:",omitempty"`
+
+	dir string
+}
+
+// AttachmentEntry is a persisted attachment of a message. Its content is
+// spooled to a file next to the message.
+type AttachmentEntry struct {
+	Name     string
+	Size     int64  `json:",omitempty"`
+	MIMEType string `json:",omitempty"`
+}
+
+// Outbox wraps a cchat.Sender to persist messages before sending them. The
+// directory must be unique to the wrapped Sender, for example by keying it
+// with the service, session and server IDs.
+//
+// Send only returns an error if the message could not be persisted. Errors
+// from the wrapped Sender keep the message in the outbox and are reported
+// through the Outbox's SendStateIndicator instead, keyed by the messages'
+// nonces. The states of messages that don't implement Noncer are not reported,
+// and such messages cannot be retried or removed by nonce.
+type Outbox struct {
+	cchat.Sender
+	// MaxAttempts is the number of failed attempts after which a message is
+	// marked as failed. Attempts that fail because the context given to Flush
+	// is done are not counted. The default is DefaultMaxAttempts.
+	MaxAttempts int
+	// IsPermanent optionally returns true if the given error from the wrapped
+	// Sender means that the message will never be sent, such as when the
+	// server rejects it, in which case the message is marked as failed right
+	// away.
+	IsPermanent func(error) bool
+	// FlushTimeout is the timeout of the flush started by SetConnectionState
+	// on reconnection. The default is DefaultFlushTimeout.
+	FlushTimeout time.Duration
+
+	dir string
+
+	// flushing is a semaphore held while flushing, so that waiting for it can
+	// be canceled.
+	flushing chan struct{}
+
+	mutex       sync.Mutex
+	cancelFlush context.CancelFunc
+	seq         uint64
+	containers  map[int]cchat.SendStateContainer
+	nextID      int
+	// progress contains the upload progress containers of messages sent in
+	// this process, keyed by their directories.
+	progress map[string]cchat.UploadProgressContainer
+}
+
+var (
+	_ cchat.Sender                   = (*Outbox)(nil)
+	_ cchat.SendStateIndicator       = (*Outbox)(nil)
+	_ cchat.ConnectionStateContainer = (*Outbox)(nil)
+)
+
+// New creates a new outbox in the given directory, creating it if it doesn't
+// exist. Messages already in the directory are kept and sent on the next
+// Flush.
+func New(dir string, sender cchat.Sender) (*Outbox, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, errors.Wrap(err, "Failed to make outbox dir")
+	}
+
+	o := &Outbox{
+		Sender:     sender,
+		dir:        dir,
+		flushing:   make(chan struct{}, 1),
+		containers: map[int]cchat.SendStateContainer{},
+		progress:   map[string]cchat.UploadProgressContainer{},
+	}
+
+	seqs, err := o.seqs()
+	if err != nil {
+		return nil, err
+	}
+	if len(seqs) > 0 {
+		o.seq = seqs[len(seqs)-1]
+	}
+
+	return o, nil
+}
+
+// Send persists the message and then flushes the outbox, sending all
+// persisted messages in order. The message is sent after all messages that
+// were queued before it. If the context is done before a flush in progress
+// finishes, the message stays in the outbox for the next flush.
+func (o *Outbox) Send(ctx context.Context, msg cchat.SendableMessage) error {
+	entry, err := o.persist(msg)
+	if err != nil {
+		return err
+	}
+
+	o.setState(ctx, entry.Nonce, cchat.SendStatePending, nil)
+
+	// Flush errors are reported through the send states.
+	o.Flush(ctx)
+	return nil
+}
+
+// Flush sends all persisted messages in order, skipping failed ones. It stops
+// at the first message that fails to send and returns its error, keeping it
+// and the messages after it in the outbox, unless the message is marked as
+// failed, in which case Flush moves on to the next message. If another flush
+// is in progress, Flush waits for it until the context is done.
+func (o *Outbox) Flush(ctx context.Context) error {
+	if err := o.lock(ctx); err != nil {
+		return err
+	}
+	defer o.unlock()
+
+	entries, err := o.Entries()
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if entry.Failed {
+			continue
+		}
+
+		if err := o.sendEntry(ctx, entry); err != nil {
+			// The caller gave up, which is not the message's fault.
+			if ctx.Err() != nil {
+				o.setState(ctx, entry.Nonce, cchat.SendStatePending, err)
+				return errors.Wrap(err, "Failed to send message")
+			}
+
+			entry.Attempts++
+			entry.Failed = entry.Attempts >= o.maxAttempts() ||
+				(o.IsPermanent != nil && o.IsPermanent(err))
+
+			if werr := entry.write(); werr != nil {
+				return werr
+			}
+
+			if entry.Failed {
+				o.setState(ctx, entry.Nonce, cchat.SendStateFailed, err)
+				continue
+			}
+
+			o.setState(ctx, entry.Nonce, cchat.SendStatePending, err)
+			return errors.Wrap(err, "Failed to send message")
+		}
+
+		if err := o.remove(entry); err != nil {
+			return errors.Wrap(err, "Failed to remove sent message")
+		}
+
+		o.setState(ctx, entry.Nonce, cchat.SendStateSent, nil)
+	}
+
+	return nil
+}
+
+// Retry marks the failed message with the given nonce as pending again and
+// flushes the outbox. It returns false if there is no such failed message.
+func (o *Outbox) Retry(ctx context.Context, nonce string) (bool, error) {
+	if nonce == "" {
+		return false, nil
+	}
+
+	if err := o.lock(ctx); err != nil {
+		return false, err
+	}
+
+	entries, err := o.Entries()
+	if err != nil {
+		o.unlock()
+		return false, err
+	}
+
+	var found bool
+
+	for _, entry := range entries {
+		if entry.Nonce == nonce && entry.Failed {
+			entry.Attempts = 0
+			entry.Failed = false
+
+			if err := entry.write(); err != nil {
+				o.unlock()
+				return false, err
+			}
+
+			found = true
+			break
+		}
+	}
+
+	o.unlock()
+
+	if !found {
+		return false, nil
+	}
+
+	o.setState(ctx, nonce, cchat.SendStatePending, nil)
+
+	// Flush errors are reported through the send states.
+	o.Flush(ctx)
+	return true, nil
+}
+
+// lock acquires the flush semaphore or returns the context's error if it is
+// done first.
+func (o *Outbox) lock(ctx context.Context) error {
+	select {
+	case o.flushing <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return errors.Wrap(ctx.Err(), "Failed to wait for flush")
+	}
+}
+
+func (o *Outbox) unlock() {
+	<-o.flushing
+}
+
+func (o *Outbox) maxAttempts() int {
+	if o.MaxAttempts > 0 {
+		return o.MaxAttempts
+	}
+	return DefaultMaxAttempts
+}
+
+func (o *Outbox) flushTimeout() time.Duration {
+	if o.FlushTimeout > 0 {
+		return o.FlushTimeout
+	}
+	return DefaultFlushTimeout
+}
+
+// SetConnectionState flushes the outbox in the background when the session is
+// connected. The flush is canceled once the session is in any other state or
+// after FlushTimeout.
+func (o *Outbox) SetConnectionState(ctx context.Context, state cchat.ConnectionState, _ error) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	if o.cancelFlush != nil {
+		o.cancelFlush()
+		o.cancelFlush = nil
+	}
+
+	if state != cchat.ConnectionStateConnected {
+		return
+	}
+
+	flushCtx, cancel := context.WithTimeout(context.Background(), o.flushTimeout())
+	o.cancelFlush = cancel
+
+	go func() {
+		// Flush errors are reported through the send states.
+		o.Flush(flushCtx)
+		cancel()
+	}()
+}
+
+// SetLatency does nothing.
+func (o *Outbox) SetLatency(context.Context, time.Duration) {}
+
+// Entries returns all persisted messages in the order that they will be sent.
+func (o *Outbox) Entries() ([]Entry, error) {
+	seqs, err := o.seqs()
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]Entry, 0, len(seqs))
+
+	for _, seq := range seqs {
+		dir := o.entryDir(seq)
+
+		b, err := ioutil.ReadFile(filepath.Join(dir, messageFile))
+		if err != nil {
+			// The message may have been removed or is still being written.
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, errors.Wrap(err, "Failed to read message")
+		}
+
+		var entry Entry
+		if err := json.Unmarshal(b, &entry); err != nil {
+			return nil, errors.Wrapf(err, "Failed to decode message %d", seq)
+		}
+		entry.dir = dir
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// Remove removes the persisted message with the given nonce, so that it will
+// not be sent. It returns false if there is no such message.
+func (o *Outbox) Remove(nonce string) (bool, error) {
+	if nonce == "" {
+		return false, nil
+	}
+
+	entries, err := o.Entries()
+	if err != nil {
+		return false, err
+	}
+
+	for _, entry := range entries {
+		if entry.Nonce == nonce {
+			return true, o.remove(entry)
+		}
+	}
+
+	return false, nil
+}
+
+func (o *Outbox) remove(entry Entry) error {
+	o.mutex.Lock()
+	delete(o.progress, entry.dir)
+	o.mutex.Unlock()
+
+	return os.RemoveAll(entry.dir)
+}
+
+// AsSendStateIndicator returns the outbox itself.
+func (o *Outbox) AsSendStateIndicator() cchat.SendStateIndicator {
+	return o
+}
+
+// SendStateIndicate subscribes the container to the states of messages in the
+// outbox. If the wrapped Sender also implements SendStateIndicator, then the
+// container is subscribed to it as well.
+func (o *Outbox) SendStateIndicate(
+	ctx context.Context, container cchat.SendStateContainer) (func(), error) {
+
+	var stopSender func()
+
+	if indicator := o.Sender.AsSendStateIndicator(); indicator != nil {
+		s, err := indicator.SendStateIndicate(ctx, container)
+		if err != nil {
+			return nil, err
+		}
+		stopSender = s
+	}
+
+	o.mutex.Lock()
+	id := o.nextID
+	o.nextID++
+	o.containers[id] = container
+	o.mutex.Unlock()
+
+	return func() {
+		o.mutex.Lock()
+		delete(o.containers, id)
+		o.mutex.Unlock()
+
+		if stopSender != nil {
+			stopSender()
+		}
+	}, nil
+}
+
+// setState reports the state of the message with the given nonce. Messages
+// without a nonce are not reported, since their states would collide.
+func (o *Outbox) setState(ctx context.Context, nonce string, s cchat.SendState, err error) {
+	if nonce == "" {
+		return
+	}
+
+	o.mutex.Lock()
+	containers := make([]cchat.SendStateContainer, 0, len(o.containers))
+	for _, container := range o.containers {
+		containers = append(containers, container)
+	}
+	o.mutex.Unlock()
+
+	for _, container := range containers {
+		container.SetSendState(ctx, nonce, s, err)
+	}
+}
+
+// persist writes the message and its attachments into a new directory. The
+// message file is written last, so that incomplete messages are skipped.
+func (o *Outbox) persist(msg cchat.SendableMessage) (Entry, error) {
+	o.mutex.Lock()
+	o.seq++
+	seq := o.seq
+	o.mutex.Unlock()
+
+	entry := Entry{
+		Content: msg.Content(),
+		dir:     o.entryDir(seq),
+	}
+
+	if noncer := msg.AsNoncer(); noncer != nil {
+		entry.Nonce = noncer.Nonce()
+	}
+	if replier := msg.AsReplier(); replier != nil {
+		entry.ReplyingTo = replier.ReplyingTo()
+	}
+
+	if err := os.Mkdir(entry.dir, 0700); err != nil {
+		return entry, errors.Wrap(err, "Failed to make message dir")
+	}
+
+	if attacher := msg.AsAttacher(); attacher != nil {
+		for i, attachment := range attacher.Attachments() {
+			if err := spool(entry.attachmentPath(i), attachment.Reader); err != nil {
+				os.RemoveAll(entry.dir)
+				return entry, errors.Wrapf(err, "Failed to spool attachment %q", attachment.Name)
+			}
+
+			entry.Attachments = append(entry.Attachments, AttachmentEntry{
+				Name:     attachment.Name,
+				Size:     attachment.Size,
+				MIMEType: attachment.MIMEType,
+			})
+		}
+	}
+
+	if err := entry.write(); err != nil {
+		os.RemoveAll(entry.dir)
+		return entry, err
+	}
+
+	// Keep reporting the upload progress while this process sends it.
+	if attacher := msg.AsAttacher(); attacher != nil {
+		if upc := attacher.AsUploadProgressContainer(); upc != nil {
+			o.mutex.Lock()
+			o.progress[entry.dir] = upc
+			o.mutex.Unlock()
+		}
+	}
+
+	return entry, nil
+}
+
+// write writes the message file of the entry.
+func (e Entry) write() error {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return errors.Wrap(err, "Failed to encode message")
+	}
+
+	// Write to a temporary file and rename it, so that a crash never leaves a
+	// partially written message behind.
+	tmp := filepath.Join(e.dir, messageFile+".tmp")
+
+	if err := ioutil.WriteFile(tmp, b, 0600); err != nil {
+		return errors.Wrap(err, "Failed to write message")
+	}
+
+	if err := os.Rename(tmp, filepath.Join(e.dir, messageFile)); err != nil {
+		return errors.Wrap(err, "Failed to write message")
+	}
+
+	return nil
+}
+
+func (o *Outbox) sendEntry(ctx context.Context, entry Entry) error {
+	o.mutex.Lock()
+	msg := message{entry: entry, upc: o.progress[entry.dir]}
+	o.mutex.Unlock()
+
+	for i, attachment := range entry.Attachments {
+		f, err := os.Open(entry.attachmentPath(i))
+		if err != nil {
+			msg.close()
+			return errors.Wrapf(err, "Failed to open spooled attachment %q", attachment.Name)
+		}
+
+		msg.attachments = append(msg.attachments, cchat.MessageAttachment{
+			Reader:   f,
+			Name:     attachment.Name,
+			Size:     attachment.Size,
+			MIMEType: attachment.MIMEType,
+		})
+	}
+
+	defer msg.close()
+
+	return o.Sender.Send(ctx, msg)
+}
+
+// seqs returns the sorted sequence numbers of all message directories.
+func (o *Outbox) seqs() ([]uint64, error) {
+	files, err := ioutil.ReadDir(o.dir)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to read outbox dir")
+	}
+
+	var seqs []uint64
+
+	for _, file := range files {
+		if !file.IsDir() {
+			continue
+		}
+
+		seq, err := strconv.ParseUint(file.Name(), 10, 64)
+		if err != nil {
+			continue
+		}
+
+		seqs = append(seqs, seq)
+	}
+
+	sort.Slice(seqs, func(i, j int) bool { return seqs[i] < seqs[j] })
+
+	return seqs, nil
+}
+
+func (o *Outbox) entryDir(seq uint64) string {
+	return filepath.Join(o.dir, fmt.Sprintf("%020d", seq))
+}
+
+func (e Entry) attachmentPath(i int) string {
+	return filepath.Join(e.dir, strconv.Itoa(i))
+}
+
+func spool(path string, r io.Reader) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// message is a SendableMessage read back from an Entry.
+type message struct {
+	entry       Entry
+	attachments []cchat.MessageAttachment
+	upc         cchat.UploadProgressContainer
+}
+
+var _ cchat.SendableMessage = (*message)(nil)
+
+func (m message) Content() string { return m.entry.Content }
+
+func (m message) AsNoncer() cchat.Noncer {
+	if m.entry.Nonce == "" {
+		return nil
+	}
+	return m
+}
+
+func (m message) Nonce() string { return m.entry.Nonce }
+
+func (m message) AsReplier() cchat.Replier {
+	if m.entry.ReplyingTo == "" {
+		return nil
+	}
+	return m
+}
+
+func (m message) ReplyingTo() cchat.ID { return m.entry.ReplyingTo }
+
+func (m message) AsAttacher() cchat.Attacher {
+	if len(m.attachments) == 0 {
+		return nil
+	}
+	return m
+}
+
+func (m message) Attachments() []cchat.MessageAttachment { return m.attachments }
+
+func (m message) AsUploadProgressContainer() cchat.UploadProgressContainer { return m.upc }
+
+func (m message) close() {
+	for _, attachment := range m.attachments {
+		attachment.Reader.(io.Closer).Close()
+	}
+}
@@ -0,0 +1,476 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/diamondburned/cchat"
+	"github.com/diamondburned/cchat/utils/empty"
+)
+
+type testMessage struct {
+	empty.SendableMessage
+	content     string
+	attachments []cchat.MessageAttachment
+	upc         cchat.UploadProgressContainer
+}
+
+func (m testMessage) Content() string        { return m.content }
+func (m testMessage) AsNoncer() cchat.Noncer { return m }
+func (m testMessage) Nonce() string          { return "nonce-" + m.content }
+
+func (m testMessage) AsAttacher() cchat.Attacher {
+	if len(m.attachments) == 0 {
+		return nil
+	}
+	return m
+}
+
+func (m testMessage) Attachments() []cchat.MessageAttachment { return m.attachments }
+
+func (m testMessage) AsUploadProgressContainer() cchat.UploadProgressContainer { return m.upc }
+
+type sender struct {
+	empty.Sender
+	offline  bool
+	rejected string // content that is always rejected
+	sent     []string
+	files    []string
+	upcs     []cchat.UploadProgressContainer
+}
+
+func (s *sender) CanAttach() bool { return true }
+
+func (s *sender) Send(_ context.Context, msg cchat.SendableMessage) error {
+	if s.offline {
+		return errors.New("offline")
+	}
+	if msg.Content() == s.rejected {
+		return errRejected
+	}
+
+	s.sent = append(s.sent, msg.Content())
+
+	if attacher := msg.AsAttacher(); attacher != nil {
+		s.upcs = append(s.upcs, attacher.AsUploadProgressContainer())
+
+		for _, attachment := range attacher.Attachments() {
+			b, err := ioutil.ReadAll(attachment)
+			if err != nil {
+				return err
+			}
+			s.files = append(s.files, attachment.Name+":"+string(b))
+		}
+	}
+
+	return nil
+}
+
+var errRejected = errors.New("rejected")
+
+type container struct{ states []cchat.SendState }
+
+type progressContainer struct{}
+
+func (progressContainer) SetUploadProgress(context.Context, int, int64, int64) {}
+
+func (c *container) SetSendState(_ context.Context, _ string, s cchat.SendState, _ error) {
+	c.states = append(c.states, s)
+}
+
+func TestOutbox(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cchat-outbox-")
+	if err != nil {
+		t.Fatal("Failed to make temp dir:", err)
+	}
+	defer os.RemoveAll(dir)
+
+	var ctx = context.Background()
+	var s = &sender{offline: true}
+
+	o, err := New(dir, s)
+	if err != nil {
+		t.Fatal("Failed to create outbox:", err)
+	}
+
+	c := &container{}
+	stop, _ := o.AsSendStateIndicator().SendStateIndicate(ctx, c)
+	defer stop()
+
+	if err := o.Send(ctx, testMessage{content: "a"}); err != nil {
+		t.Fatal("Failed to send a:", err)
+	}
+	if err := o.Send(ctx, testMessage{
+		content: "b",
+		attachments: []cchat.MessageAttachment{
+			{Reader: strings.NewReader("hello"), Name: "hello.txt"},
+		},
+	}); err != nil {
+		t.Fatal("Failed to send b:", err)
+	}
+
+	entries, err := o.Entries()
+	if err != nil {
+		t.Fatal("Failed to get entries:", err)
+	}
+	if len(entries) != 2 || entries[0].Nonce != "nonce-a" || entries[1].Nonce != "nonce-b" {
+		t.Fatalf("Unexpected entries: %#v", entries)
+	}
+
+	// Reopen the outbox as if the program was restarted.
+	o, err = New(dir, s)
+	if err != nil {
+		t.Fatal("Failed to reopen outbox:", err)
+	}
+
+	s.offline = false
+
+	if err := o.Send(ctx, testMessage{content: "c"}); err != nil {
+		t.Fatal("Failed to send c:", err)
+	}
+
+	if !strsleq(s.sent, []string{"a", "b", "c"}) {
+		t.Fatal("Unexpected sent messages:", s.sent)
+	}
+	if !strsleq(s.files, []string{"hello.txt:hello"}) {
+		t.Fatal("Unexpected sent files:", s.files)
+	}
+
+	entries, _ = o.Entries()
+	if len(entries) != 0 {
+		t.Fatal("Unexpected entries left:", entries)
+	}
+
+	var expect = []cchat.SendState{
+		cchat.SendStatePending, // a queued
+		cchat.SendStatePending, // a failed
+		cchat.SendStatePending, // b queued
+		cchat.SendStatePending, // a failed
+	}
+	if !stateseq(c.states, expect) {
+		t.Fatal("Unexpected states:", c.states)
+	}
+}
+
+func TestOutboxRemove(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cchat-outbox-")
+	if err != nil {
+		t.Fatal("Failed to make temp dir:", err)
+	}
+	defer os.RemoveAll(dir)
+
+	s := &sender{offline: true}
+
+	o, err := New(dir, s)
+	if err != nil {
+		t.Fatal("Failed to create outbox:", err)
+	}
+
+	o.Send(context.Background(), testMessage{content: "a"})
+
+	if ok, err := o.Remove("nonce-a"); !ok || err != nil {
+		t.Fatal("Failed to remove a:", ok, err)
+	}
+
+	s.offline = false
+
+	if err := o.Flush(context.Background()); err != nil {
+		t.Fatal("Failed to flush:", err)
+	}
+	if len(s.sent) != 0 {
+		t.Fatal("Removed message was sent:", s.sent)
+	}
+}
+
+// plainMessage is a message without a nonce.
+type plainMessage struct {
+	empty.SendableMessage
+	content string
+}
+
+func (m plainMessage) Content() string { return m.content }
+
+func TestOutboxNoNonce(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cchat-outbox-")
+	if err != nil {
+		t.Fatal("Failed to make temp dir:", err)
+	}
+	defer os.RemoveAll(dir)
+
+	s := &sender{}
+
+	o, err := New(dir, s)
+	if err != nil {
+		t.Fatal("Failed to create outbox:", err)
+	}
+
+	c := &container{}
+	stop, _ := o.SendStateIndicate(context.Background(), c)
+	defer stop()
+
+	if err := o.Send(context.Background(), plainMessage{content: "a"}); err != nil {
+		t.Fatal("Failed to send:", err)
+	}
+
+	if !strsleq(s.sent, []string{"a"}) {
+		t.Fatal("Unexpected sent messages:", s.sent)
+	}
+	if len(c.states) != 0 {
+		t.Fatal("Unexpected states of message without nonce:", c.states)
+	}
+}
+
+func stateseq(s1, s2 []cchat.SendState) bool {
+	if len(s1) != len(s2) {
+		return false
+	}
+	for i := range s1 {
+		if s1[i] != s2[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func strsleq(s1, s2 []string) bool {
+	if len(s1) != len(s2) {
+		return false
+	}
+	for i := range s1 {
+		if s1[i] != s2[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestOutboxReconnect(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cchat-outbox-")
+	if err != nil {
+		t.Fatal("Failed to make temp dir:", err)
+	}
+	defer os.RemoveAll(dir)
+
+	s := &sender{offline: true}
+
+	o, err := New(dir, s)
+	if err != nil {
+		t.Fatal("Failed to create outbox:", err)
+	}
+
+	o.Send(context.Background(), testMessage{content: "a"})
+
+	c := &container{}
+	stop, _ := o.SendStateIndicate(context.Background(), c)
+	defer stop()
+
+	s.offline = false
+	o.SetConnectionState(context.Background(), cchat.ConnectionStateConnected, nil)
+
+	// Wait for the background flush by acquiring the flush lock.
+	for i := 0; i < 100; i++ {
+		o.lock(context.Background())
+		n := len(c.states)
+		o.unlock()
+
+		if n > 0 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if !strsleq(s.sent, []string{"a"}) {
+		t.Fatal("Unexpected sent messages after reconnecting:", s.sent)
+	}
+}
+
+// blockingSender blocks until the context is done.
+type blockingSender struct {
+	empty.Sender
+	started chan struct{}
+	done    chan error
+}
+
+func (blockingSender) CanAttach() bool { return false }
+
+func (s blockingSender) Send(ctx context.Context, _ cchat.SendableMessage) error {
+	s.started <- struct{}{}
+	<-ctx.Done()
+	s.done <- ctx.Err()
+	return ctx.Err()
+}
+
+func TestOutboxBlockedFlush(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cchat-outbox-")
+	if err != nil {
+		t.Fatal("Failed to make temp dir:", err)
+	}
+	defer os.RemoveAll(dir)
+
+	s := blockingSender{
+		started: make(chan struct{}, 1),
+		done:    make(chan error, 1),
+	}
+
+	o, err := New(dir, s)
+	if err != nil {
+		t.Fatal("Failed to create outbox:", err)
+	}
+
+	if _, err := o.persist(testMessage{content: "a"}); err != nil {
+		t.Fatal("Failed to persist message:", err)
+	}
+
+	o.SetConnectionState(context.Background(), cchat.ConnectionStateConnected, nil)
+	<-s.started
+
+	// Send gives up waiting for the background flush once its context is
+	// done.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	sent := make(chan error, 1)
+	go func() { sent <- o.Send(ctx, testMessage{content: "b"}) }()
+
+	select {
+	case err := <-sent:
+		if err != nil {
+			t.Fatal("Unexpected Send error:", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Send blocked on the background flush")
+	}
+
+	// Disconnecting cancels the background flush.
+	o.SetConnectionState(context.Background(), cchat.ConnectionStateDisconnected, nil)
+
+	select {
+	case err := <-s.done:
+		if err != context.Canceled {
+			t.Fatal("Unexpected background flush error:", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Background flush was not canceled")
+	}
+
+	entries, err := o.Entries()
+	if err != nil {
+		t.Fatal("Failed to read entries:", err)
+	}
+	if len(entries) != 2 {
+		t.Fatal("Unexpected number of entries:", len(entries))
+	}
+}
+
+func TestOutboxFailed(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cchat-outbox-")
+	if err != nil {
+		t.Fatal("Failed to make temp dir:", err)
+	}
+	defer os.RemoveAll(dir)
+
+	var ctx = context.Background()
+	var s = &sender{rejected: "a"}
+
+	o, err := New(dir, s)
+	if err != nil {
+		t.Fatal("Failed to create outbox:", err)
+	}
+	o.IsPermanent = func(err error) bool { return err == errRejected }
+
+	c := &container{}
+	stop, _ := o.SendStateIndicate(ctx, c)
+	defer stop()
+
+	upc := progressContainer{}
+
+	o.Send(ctx, testMessage{content: "a"})
+	o.Send(ctx, testMessage{
+		content: "b",
+		attachments: []cchat.MessageAttachment{
+			{Reader: strings.NewReader("hello"), Name: "hello.txt"},
+		},
+		upc: upc,
+	})
+
+	if !strsleq(s.sent, []string{"b"}) {
+		t.Fatal("Unexpected sent messages:", s.sent)
+	}
+	if len(s.upcs) != 1 || s.upcs[0] != upc {
+		t.Fatal("Unexpected upload progress containers:", s.upcs)
+	}
+
+	var expect = []cchat.SendState{
+		cchat.SendStatePending, // a queued
+		cchat.SendStateFailed,  // a rejected
+		cchat.SendStatePending, // b queued
+		cchat.SendStateSent,    // b sent
+	}
+	if !stateseq(c.states, expect) {
+		t.Fatal("Unexpected states:", c.states)
+	}
+
+	entries, _ := o.Entries()
+	if len(entries) != 1 || !entries[0].Failed {
+		t.Fatalf("Unexpected entries left: %#v", entries)
+	}
+
+	// Failed messages are only sent again once retried.
+	s.rejected = ""
+
+	if err := o.Flush(ctx); err != nil {
+		t.Fatal("Failed to flush:", err)
+	}
+	if !strsleq(s.sent, []string{"b"}) {
+		t.Fatal("Failed message was sent without retrying:", s.sent)
+	}
+
+	if ok, err := o.Retry(ctx, "nonce-a"); !ok || err != nil {
+		t.Fatal("Failed to retry a:", ok, err)
+	}
+	if !strsleq(s.sent, []string{"b", "a"}) {
+		t.Fatal("Unexpected sent messages after retry:", s.sent)
+	}
+}
+
+func TestOutboxMaxAttempts(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cchat-outbox-")
+	if err != nil {
+		t.Fatal("Failed to make temp dir:", err)
+	}
+	defer os.RemoveAll(dir)
+
+	var ctx = context.Background()
+	var s = &sender{offline: true}
+
+	o, err := New(dir, s)
+	if err != nil {
+		t.Fatal("Failed to create outbox:", err)
+	}
+	o.MaxAttempts = 2
+
+	o.Send(ctx, testMessage{content: "a"})
+
+	entries, _ := o.Entries()
+	if len(entries) != 1 || entries[0].Attempts != 1 || entries[0].Failed {
+		t.Fatalf("Unexpected entries after first attempt: %#v", entries)
+	}
+
+	// Attempts are kept across restarts.
+	o, _ = New(dir, s)
+	o.MaxAttempts = 2
+
+	if err := o.Flush(ctx); err != nil {
+		t.Fatal("Unexpected error after the message failed:", err)
+	}
+
+	entries, _ = o.Entries()
+	if len(entries) != 1 || entries[0].Attempts != 2 || !entries[0].Failed {
+		t.Fatalf("Unexpected entries after last attempt: %#v", entries)
+	}
+}
@@ -0,0 +1,117 @@
+// Package progress provides reader wrappers for backends to report attachment
+// upload progress to an UploadProgressContainer during Send.
+//
+// Usage
+//
+// Backends can wrap all attachments of a message at the start of Send:
+//
+//    if attacher := msg.AsAttacher(); attacher != nil {
+//        attachments := progress.WrapAttachments(ctx, attacher)
+//        // Upload attachments as usual.
+//    }
+//
+package progress
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/diamondburned/cchat"
+)
+
+// ReportInterval is the minimum interval between two progress reports of the
+// same reader. The final report at the end of the reader is always sent,
+// unless the same number of bytes has already been reported.
+var ReportInterval = 100 * time.Millisecond
+
+// Reader is a reader that reports the number of bytes read to a container. It
+// also stops reading once the context is cancelled, even if the backend's
+// upload code does not use the context.
+type Reader struct {
+	r     io.Reader
+	ctx   context.Context
+	upc   cchat.UploadProgressContainer
+	index int
+	total int64
+
+	mutex        sync.Mutex
+	sent         int64
+	reported     time.Time
+	reportedSent int64
+}
+
+var _ io.Reader = (*Reader)(nil)
+
+// NewReader wraps the given reader to report its progress to the container as
+// the attachment at the given index. The container can be nil, in which case
+// only cancellation is handled. The total is the size of the attachment or 0
+// if it is unknown.
+func NewReader(
+	ctx context.Context, r io.Reader,
+	upc cchat.UploadProgressContainer, index int, total int64) *Reader {
+
+	return &Reader{
+		r:     r,
+		ctx:   ctx,
+		upc:   upc,
+		index: index,
+		total: total,
+		// Report an empty reader at its end.
+		reportedSent: -1,
+	}
+}
+
+// Read reads from the underlying reader and reports the progress. It returns
+// the context's error if the context is cancelled.
+func (r *Reader) Read(b []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	n, err := r.r.Read(b)
+
+	r.mutex.Lock()
+	r.sent += int64(n)
+	sent := r.sent
+
+	now := time.Now()
+	report := sent != r.reportedSent &&
+		(err == io.EOF || now.Sub(r.reported) >= ReportInterval)
+	if report {
+		r.reported = now
+		r.reportedSent = sent
+	}
+	r.mutex.Unlock()
+
+	if report && r.upc != nil {
+		r.upc.SetUploadProgress(r.ctx, r.index, sent, r.total)
+	}
+
+	return n, err
+}
+
+// Sent returns the number of bytes read so far.
+func (r *Reader) Sent() int64 {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.sent
+}
+
+// WrapAttachments returns the attacher's attachments with each reader wrapped
+// in a Reader that reports to the attacher's UploadProgressContainer, if any.
+// The returned attachments keep their names and metadata.
+func WrapAttachments(ctx context.Context, attacher cchat.Attacher) []cchat.MessageAttachment {
+	attachments := attacher.Attachments()
+	upc := attacher.AsUploadProgressContainer()
+
+	wrapped := make([]cchat.MessageAttachment, len(attachments))
+	for i, attachment := range attachments {
+		wrapped[i] = attachment
+		wrapped[i].Reader = NewReader(ctx, attachment.Reader, upc, i, attachment.Size)
+	}
+
+	return wrapped
+}
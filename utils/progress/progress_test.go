@@ -0,0 +1,84 @@
+package progress
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/diamondburned/cchat"
+	"github.com/go-test/deep"
+)
+
+type report struct {
+	index       int
+	sent, total int64
+}
+
+type container struct{ reports []report }
+
+func (c *container) SetUploadProgress(_ context.Context, index int, sent, total int64) {
+	c.reports = append(c.reports, report{index, sent, total})
+}
+
+type attacher struct {
+	attachments []cchat.MessageAttachment
+	upc         cchat.UploadProgressContainer
+}
+
+func (a attacher) Attachments() []cchat.MessageAttachment { return a.attachments }
+
+func (a attacher) AsUploadProgressContainer() cchat.UploadProgressContainer { return a.upc }
+
+func TestWrapAttachments(t *testing.T) {
+	defer func(interval time.Duration) { ReportInterval = interval }(ReportInterval)
+	ReportInterval = 0
+
+	upc := &container{}
+	wrapped := WrapAttachments(context.Background(), attacher{
+		attachments: []cchat.MessageAttachment{
+			{Reader: strings.NewReader("hello"), Name: "a.txt", Size: 5},
+			{Reader: strings.NewReader("world!"), Name: "b.txt"},
+		},
+		upc: upc,
+	})
+
+	for _, attachment := range wrapped {
+		if _, err := io.Copy(ioutil.Discard, attachment); err != nil {
+			t.Fatal("Failed to read attachment:", err)
+		}
+	}
+
+	var expect = []report{
+		{0, 5, 5},
+		{1, 6, 0},
+	}
+	if eq := deep.Equal(upc.reports, expect); eq != nil {
+		t.Fatal("Unexpected reports:", eq)
+	}
+	if wrapped[0].Name != "a.txt" || wrapped[0].Size != 5 {
+		t.Fatal("Attachment metadata not kept:", wrapped[0])
+	}
+}
+
+func TestReaderCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	r := NewReader(ctx, bytes.NewReader(make([]byte, 10)), nil, 0, 10)
+
+	if _, err := r.Read(make([]byte, 4)); err != nil {
+		t.Fatal("Unexpected error before cancel:", err)
+	}
+
+	cancel()
+
+	if _, err := r.Read(make([]byte, 4)); err != context.Canceled {
+		t.Fatal("Expected context.Canceled, got", err)
+	}
+	if sent := r.Sent(); sent != 4 {
+		t.Fatal("Unexpected sent bytes:", sent)
+	}
+}
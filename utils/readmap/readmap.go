@@ -0,0 +1,152 @@
+// Package readmap provides a ReadContainer implementation that keeps track of
+// which message each user has last read. Frontends can use it to render "seen
+// by" avatars under messages.
+package readmap
+
+import (
+	"context"
+	"sync"
+
+	"github.com/diamondburned/cchat"
+)
+
+var _ cchat.ReadContainer = (*Map)(nil)
+
+// Map is a thread-safe ReadContainer that maps users to the last message that
+// they have read. The zero-value is a valid Map.
+type Map struct {
+	// OnUpdate is called with the IDs of messages whose list of readers has
+	// changed. It is called outside of the lock, so it may call other methods
+	// on the Map. OnUpdate must not be changed after the Map is used.
+	OnUpdate func(messageIDs []cchat.ID)
+
+	mutex sync.RWMutex
+	users map[cchat.ID]cchat.ReadIndication // user ID -> indication
+	reads map[cchat.ID][]cchat.ID           // message ID -> user IDs
+}
+
+// New creates a new Map with the given update callback, which can be nil.
+func New(onUpdate func(messageIDs []cchat.ID)) *Map {
+	return &Map{OnUpdate: onUpdate}
+}
+
+// AddIndications upserts the given indications, moving each user to their new
+// message.
+func (m *Map) AddIndications(_ context.Context, indications []cchat.ReadIndication) {
+	var updated []cchat.ID
+
+	m.mutex.Lock()
+
+	if m.users == nil {
+		m.users = make(map[cchat.ID]cchat.ReadIndication, len(indications))
+		m.reads = make(map[cchat.ID][]cchat.ID, len(indications))
+	}
+
+	for _, indication := range indications {
+		if indication.User == nil {
+			continue
+		}
+
+		userID := indication.User.ID()
+
+		if old, ok := m.users[userID]; ok {
+			m.removeReader(old.MessageID, userID)
+			updated = appendUnique(updated, old.MessageID)
+		}
+
+		m.users[userID] = indication
+		m.reads[indication.MessageID] = append(m.reads[indication.MessageID], userID)
+		updated = appendUnique(updated, indication.MessageID)
+	}
+
+	m.mutex.Unlock()
+
+	m.update(updated)
+}
+
+// DeleteIndications removes the users with the given IDs from the map.
+func (m *Map) DeleteIndications(_ context.Context, authorIDs []cchat.ID) {
+	var updated []cchat.ID
+
+	m.mutex.Lock()
+
+	for _, userID := range authorIDs {
+		old, ok := m.users[userID]
+		if !ok {
+			continue
+		}
+
+		delete(m.users, userID)
+		m.removeReader(old.MessageID, userID)
+		updated = appendUnique(updated, old.MessageID)
+	}
+
+	m.mutex.Unlock()
+
+	m.update(updated)
+}
+
+// ReadBy returns the list of users whose last read message is the message
+// with the given ID. The users are in the order that they have read the
+// message.
+func (m *Map) ReadBy(messageID cchat.ID) []cchat.User {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	userIDs := m.reads[messageID]
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	users := make([]cchat.User, len(userIDs))
+	for i, userID := range userIDs {
+		users[i] = m.users[userID].User
+	}
+
+	return users
+}
+
+// LastRead returns the ID of the last message that the user with the given ID
+// has read. False is returned if the user is not in the map.
+func (m *Map) LastRead(userID cchat.ID) (cchat.ID, bool) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	indication, ok := m.users[userID]
+	return indication.MessageID, ok
+}
+
+// removeReader removes the user ID from the message's readers. The caller must
+// hold the lock.
+func (m *Map) removeReader(messageID, userID cchat.ID) {
+	userIDs := m.reads[messageID]
+
+	for i, id := range userIDs {
+		if id == userID {
+			userIDs = append(userIDs[:i], userIDs[i+1:]...)
+			break
+		}
+	}
+
+	if len(userIDs) == 0 {
+		delete(m.reads, messageID)
+		return
+	}
+
+	m.reads[messageID] = userIDs
+}
+
+func (m *Map) update(messageIDs []cchat.ID) {
+	if m.OnUpdate != nil && len(messageIDs) > 0 {
+		m.OnUpdate(messageIDs)
+	}
+}
+
+func appendUnique(ids []cchat.ID, id cchat.ID) []cchat.ID {
+	for _, existing := range ids {
+		if existing == id {
+			return ids
+		}
+	}
+	return append(ids, id)
+}
@@ -0,0 +1,69 @@
+package readmap
+
+import (
+	"context"
+	"testing"
+
+	"github.com/diamondburned/cchat"
+	"github.com/go-test/deep"
+)
+
+type user string
+
+func (u user) ID() cchat.ID { return cchat.ID(u) }
+
+func (u user) Name(context.Context, cchat.LabelContainer) (func(), error) {
+	return func() {}, nil
+}
+
+func readers(m *Map, messageID cchat.ID) []cchat.ID {
+	var ids []cchat.ID
+	for _, u := range m.ReadBy(messageID) {
+		ids = append(ids, u.ID())
+	}
+	return ids
+}
+
+func TestMap(t *testing.T) {
+	var updated [][]cchat.ID
+	m := New(func(ids []cchat.ID) { updated = append(updated, ids) })
+
+	m.AddIndications(context.Background(), []cchat.ReadIndication{
+		{User: user("a"), MessageID: "1"},
+		{User: user("b"), MessageID: "1"},
+		{User: user("c"), MessageID: "2"},
+	})
+
+	if eq := deep.Equal(readers(m, "1"), []cchat.ID{"a", "b"}); eq != nil {
+		t.Error("Unexpected readers of 1:", eq)
+	}
+
+	// Move a to message 2.
+	m.AddIndications(context.Background(), []cchat.ReadIndication{
+		{User: user("a"), MessageID: "2"},
+	})
+
+	if eq := deep.Equal(readers(m, "1"), []cchat.ID{"b"}); eq != nil {
+		t.Error("Unexpected readers of 1 after move:", eq)
+	}
+	if eq := deep.Equal(readers(m, "2"), []cchat.ID{"c", "a"}); eq != nil {
+		t.Error("Unexpected readers of 2 after move:", eq)
+	}
+
+	m.DeleteIndications(context.Background(), []cchat.ID{"b", "unknown"})
+
+	if r := readers(m, "1"); r != nil {
+		t.Error("Unexpected readers of 1 after delete:", r)
+	}
+	if _, ok := m.LastRead("b"); ok {
+		t.Error("Deleted user b still has a last read message")
+	}
+	if id, _ := m.LastRead("a"); id != "2" {
+		t.Errorf("Unexpected last read message for a: %q", id)
+	}
+
+	var expect = [][]cchat.ID{{"1", "2"}, {"1", "2"}, {"1"}}
+	if eq := deep.Equal(updated, expect); eq != nil {
+		t.Error("Unexpected updates:", eq)
+	}
+}
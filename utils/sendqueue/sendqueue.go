@@ -0,0 +1,363 @@
+// Package sendqueue provides a frontend-side queue that sends messages in
+// order through a cchat.Sender, reports their states to a SendStateContainer
+// and retries failed messages with exponential backoff.
+//
+// Usage
+//
+// The Queue is also a SendStateContainer, so it can be subscribed to the
+// backend to receive the states of asynchronously sent messages:
+//
+//    q := sendqueue.New(sender, frontendContainer)
+//    defer q.Close()
+//
+//    if indicator := sender.AsSendStateIndicator(); indicator != nil {
+//        stop, err := indicator.SendStateIndicate(ctx, q)
+//    }
+//
+//    q.Send(msg)
+//
+package sendqueue
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/diamondburned/cchat"
+)
+
+// Default values for the Queue's fields.
+const (
+	DefaultMaxAttempts = 5
+	DefaultTimeout     = time.Minute
+	DefaultMinBackoff  = time.Second
+	DefaultMaxBackoff  = time.Minute
+)
+
+// ErrNotRewindable is the error of a message that cannot be sent again because
+// its attachment readers cannot be rewound.
+var ErrNotRewindable = errors.New("sendqueue: attachments cannot be rewound")
+
+// MaxRecent is the maximum number of sent messages that the queue remembers
+// in case the backend reports them as failed later.
+const MaxRecent = 64
+
+// Backoff returns the exponential backoff duration before the given retry
+// attempt, which starts from 1. The duration is bounded by DefaultMinBackoff
+// and DefaultMaxBackoff.
+func Backoff(attempt int) time.Duration {
+	d := DefaultMinBackoff
+	for i := 1; i < attempt && d < DefaultMaxBackoff; i++ {
+		d *= 2
+	}
+	if d > DefaultMaxBackoff {
+		d = DefaultMaxBackoff
+	}
+	return d
+}
+
+// Queue sends messages one by one in the order that they are queued. A message
+// that fails is retried until it's sent or MaxAttempts is reached, after which
+// it's marked as failed and the queue moves on. Failed messages can be retried
+// manually using Retry.
+//
+// Messages with attachments are only retried if all attachment readers
+// implement io.Seeker, since the readers are consumed by the first attempt.
+// The readers are rewound before every attempt after the first one.
+//
+// States are keyed by the messages' nonces, so messages that don't implement
+// Noncer are still sent, but their states are not reported and they cannot be
+// retried.
+type Queue struct {
+	// MaxAttempts is the maximum number of attempts for each message. The
+	// default is DefaultMaxAttempts.
+	MaxAttempts int
+	// Timeout is the timeout of each attempt. The default is DefaultTimeout.
+	Timeout time.Duration
+	// Backoff returns the duration to wait before the given retry attempt. The
+	// default is the Backoff function.
+	Backoff func(attempt int) time.Duration
+
+	sender    cchat.Sender
+	container cchat.SendStateContainer
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mutex   sync.Mutex
+	queue   []entry
+	failed  map[string]cchat.SendableMessage
+	sent    map[string]cchat.SendableMessage
+	recent  []string // order of sent nonces
+	running bool
+}
+
+var _ cchat.SendStateContainer = (*Queue)(nil)
+
+// New creates a new queue that sends messages through the given sender and
+// reports their states to the given container, which can be nil.
+func New(sender cchat.Sender, container cchat.SendStateContainer) *Queue {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Queue{
+		sender:    sender,
+		container: container,
+		ctx:       ctx,
+		cancel:    cancel,
+		failed:    map[string]cchat.SendableMessage{},
+		sent:      map[string]cchat.SendableMessage{},
+	}
+}
+
+// entry is a queued message.
+type entry struct {
+	msg cchat.SendableMessage
+	// attempted is true if the message was given to the sender before, so its
+	// attachments must be rewound.
+	attempted bool
+}
+
+// Close cancels all sending and waiting messages and waits for the queue to
+// stop. Messages that were not sent are reported as failed. Queued messages
+// are not given to the sender anymore.
+func (q *Queue) Close() {
+	q.cancel()
+	q.wg.Wait()
+}
+
+// Send queues the message to be sent and marks it as pending. It does not
+// block.
+func (q *Queue) Send(msg cchat.SendableMessage) {
+	q.setState(nonce(msg), cchat.SendStatePending, nil)
+	q.enqueue(entry{msg: msg})
+}
+
+// Retry queues the failed message with the given nonce to be sent again. It
+// returns false if there is no failed message with that nonce, or if the
+// message has attachments that cannot be rewound, in which case it stays
+// failed.
+func (q *Queue) Retry(nonce string) bool {
+	q.mutex.Lock()
+	msg, ok := q.failed[nonce]
+	if ok && rewindable(msg) {
+		delete(q.failed, nonce)
+	} else {
+		ok = false
+	}
+	q.mutex.Unlock()
+
+	if !ok {
+		return false
+	}
+
+	q.setState(nonce, cchat.SendStatePending, nil)
+	q.enqueue(entry{msg: msg, attempted: true})
+	return true
+}
+
+// SetSendState forwards the state reported by the backend to the queue's
+// container. Messages that the backend reports as failed can be retried using
+// Retry. States without a nonce are ignored.
+func (q *Queue) SetSendState(ctx context.Context, nonce string, state cchat.SendState, err error) {
+	if nonce == "" {
+		return
+	}
+
+	if state != cchat.SendStatePending {
+		q.mutex.Lock()
+		if msg, ok := q.sent[nonce]; ok {
+			delete(q.sent, nonce)
+			if state == cchat.SendStateFailed {
+				q.failed[nonce] = msg
+			}
+		}
+		q.mutex.Unlock()
+	}
+
+	if q.container != nil {
+		q.container.SetSendState(ctx, nonce, state, err)
+	}
+}
+
+func (q *Queue) enqueue(e entry) {
+	q.mutex.Lock()
+	q.queue = append(q.queue, e)
+
+	start := !q.running
+	q.running = true
+	q.mutex.Unlock()
+
+	if start {
+		q.wg.Add(1)
+		go q.run()
+	}
+}
+
+func (q *Queue) run() {
+	defer q.wg.Done()
+
+	for {
+		q.mutex.Lock()
+		if len(q.queue) == 0 {
+			q.running = false
+			q.mutex.Unlock()
+			return
+		}
+
+		e := q.queue[0]
+		q.queue = q.queue[1:]
+		q.mutex.Unlock()
+
+		// Drain the queue without sending once the queue is closed.
+		if err := q.ctx.Err(); err != nil {
+			q.fail(nonce(e.msg), e.msg, err)
+			continue
+		}
+
+		q.send(e)
+	}
+}
+
+func (q *Queue) send(e entry) {
+	var msg = e.msg
+	var nonce = nonce(msg)
+	var maxAttempts = q.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultMaxAttempts
+	}
+
+	for attempt := 1; ; attempt++ {
+		if e.attempted {
+			if err := rewind(msg); err != nil {
+				q.fail(nonce, msg, err)
+				return
+			}
+		}
+		e.attempted = true
+
+		err := q.sendOnce(msg)
+		if err == nil {
+			q.remember(nonce, msg)
+			q.setState(nonce, cchat.SendStateSent, nil)
+			return
+		}
+
+		if attempt >= maxAttempts || q.ctx.Err() != nil || !rewindable(msg) {
+			q.fail(nonce, msg, err)
+			return
+		}
+
+		// Keep the message pending while waiting, but let the frontend know
+		// why.
+		q.setState(nonce, cchat.SendStatePending, err)
+
+		select {
+		case <-time.After(q.backoff(attempt)):
+		case <-q.ctx.Done():
+			q.fail(nonce, msg, q.ctx.Err())
+			return
+		}
+	}
+}
+
+func (q *Queue) sendOnce(msg cchat.SendableMessage) error {
+	var timeout = q.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(q.ctx, timeout)
+	defer cancel()
+
+	return q.sender.Send(ctx, msg)
+}
+
+func (q *Queue) backoff(attempt int) time.Duration {
+	if q.Backoff != nil {
+		return q.Backoff(attempt)
+	}
+	return Backoff(attempt)
+}
+
+// remember keeps the sent message around for MaxRecent messages, so that it
+// can be retried if the backend reports it as failed.
+func (q *Queue) remember(nonce string, msg cchat.SendableMessage) {
+	if nonce == "" {
+		return
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	q.sent[nonce] = msg
+	q.recent = append(q.recent, nonce)
+
+	if len(q.recent) > MaxRecent {
+		delete(q.sent, q.recent[0])
+		q.recent = q.recent[1:]
+	}
+}
+
+func (q *Queue) fail(nonce string, msg cchat.SendableMessage, err error) {
+	if nonce != "" {
+		q.mutex.Lock()
+		q.failed[nonce] = msg
+		q.mutex.Unlock()
+	}
+
+	q.setState(nonce, cchat.SendStateFailed, err)
+}
+
+// setState reports the state of the message with the given nonce. Messages
+// without a nonce are not reported, since their states would collide.
+func (q *Queue) setState(nonce string, state cchat.SendState, err error) {
+	if nonce != "" && q.container != nil {
+		q.container.SetSendState(q.ctx, nonce, state, err)
+	}
+}
+
+func nonce(msg cchat.SendableMessage) string {
+	if noncer := msg.AsNoncer(); noncer != nil {
+		return noncer.Nonce()
+	}
+	return ""
+}
+
+// rewindable returns true if all attachment readers can be rewound.
+func rewindable(msg cchat.SendableMessage) bool {
+	attacher := msg.AsAttacher()
+	if attacher == nil {
+		return true
+	}
+
+	for _, attachment := range attacher.Attachments() {
+		if _, ok := attachment.Reader.(io.Seeker); !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// rewind seeks all attachment readers back to the start.
+func rewind(msg cchat.SendableMessage) error {
+	attacher := msg.AsAttacher()
+	if attacher == nil {
+		return nil
+	}
+
+	for _, attachment := range attacher.Attachments() {
+		seeker, ok := attachment.Reader.(io.Seeker)
+		if !ok {
+			return ErrNotRewindable
+		}
+		if _, err := seeker.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,335 @@
+package sendqueue
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/diamondburned/cchat"
+	"github.com/diamondburned/cchat/utils/empty"
+)
+
+type message struct {
+	empty.SendableMessage
+	nonce string
+}
+
+func (m message) Content() string        { return m.nonce }
+func (m message) AsNoncer() cchat.Noncer { return m }
+func (m message) Nonce() string          { return m.nonce }
+
+type sender struct {
+	empty.Sender
+	mutex sync.Mutex
+	fails map[string]int // nonce -> remaining failures
+	sent  []string
+}
+
+func (s *sender) CanAttach() bool { return false }
+
+func (s *sender) Send(_ context.Context, msg cchat.SendableMessage) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.fails[msg.Content()] > 0 {
+		s.fails[msg.Content()]--
+		return errors.New("failed")
+	}
+
+	s.sent = append(s.sent, msg.Content())
+	return nil
+}
+
+type state struct {
+	state cchat.SendState
+	err   bool
+}
+
+type container struct {
+	mutex  sync.Mutex
+	states map[string][]state
+	done   chan string
+}
+
+func (c *container) SetSendState(_ context.Context, nonce string, s cchat.SendState, err error) {
+	c.mutex.Lock()
+	c.states[nonce] = append(c.states[nonce], state{s, err != nil})
+	c.mutex.Unlock()
+
+	if s != cchat.SendStatePending {
+		c.done <- nonce
+	}
+}
+
+func newQueue(s *sender) (*Queue, *container) {
+	c := &container{states: map[string][]state{}, done: make(chan string, 10)}
+
+	q := New(s, c)
+	q.MaxAttempts = 2
+	q.Backoff = func(int) time.Duration { return 0 }
+
+	return q, c
+}
+
+func TestQueue(t *testing.T) {
+	s := &sender{fails: map[string]int{"b": 1, "c": 2}}
+	q, c := newQueue(s)
+	defer q.Close()
+
+	q.Send(message{nonce: "a"})
+	q.Send(message{nonce: "b"})
+	q.Send(message{nonce: "c"})
+
+	for i := 0; i < 3; i++ {
+		<-c.done
+	}
+
+	if !strsleq(s.sent, []string{"a", "b"}) {
+		t.Fatal("Unexpected sent messages:", s.sent)
+	}
+
+	var expect = map[string][]state{
+		"a": {{cchat.SendStatePending, false}, {cchat.SendStateSent, false}},
+		"b": {
+			{cchat.SendStatePending, false},
+			{cchat.SendStatePending, true},
+			{cchat.SendStateSent, false},
+		},
+		"c": {
+			{cchat.SendStatePending, false},
+			{cchat.SendStatePending, true},
+			{cchat.SendStateFailed, true},
+		},
+	}
+
+	for nonce, states := range expect {
+		if got := c.states[nonce]; !stateseq(got, states) {
+			t.Errorf("Unexpected states for %q: %v", nonce, got)
+		}
+	}
+
+	if !q.Retry("c") {
+		t.Fatal("Failed to retry c")
+	}
+	<-c.done
+
+	if !strsleq(s.sent, []string{"a", "b", "c"}) {
+		t.Fatal("Unexpected sent messages after retry:", s.sent)
+	}
+	if q.Retry("c") {
+		t.Fatal("Unexpected retry of sent message")
+	}
+}
+
+// plainMessage is a message without a nonce.
+type plainMessage struct {
+	empty.SendableMessage
+	content string
+}
+
+func (m plainMessage) Content() string { return m.content }
+
+func TestQueueNoNonce(t *testing.T) {
+	s := &sender{fails: map[string]int{"a": 2}}
+	q, c := newQueue(s)
+	defer q.Close()
+
+	q.Send(plainMessage{content: "a"})
+	q.Send(message{nonce: "b"})
+	<-c.done
+
+	if !strsleq(s.sent, []string{"b"}) {
+		t.Fatal("Unexpected sent messages:", s.sent)
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if states, ok := c.states[""]; ok {
+		t.Fatal("Unexpected states of message without nonce:", states)
+	}
+}
+
+func TestQueueBackendFailure(t *testing.T) {
+	s := &sender{}
+	q, c := newQueue(s)
+	defer q.Close()
+
+	q.Send(message{nonce: "a"})
+	<-c.done
+
+	// The backend reports that the message failed after Send returned.
+	q.SetSendState(context.Background(), "a", cchat.SendStateFailed, errors.New("failed"))
+	<-c.done
+
+	if !q.Retry("a") {
+		t.Fatal("Failed to retry message failed by the backend")
+	}
+	<-c.done
+
+	if !strsleq(s.sent, []string{"a", "a"}) {
+		t.Fatal("Unexpected sent messages:", s.sent)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	var tests = []struct {
+		attempt int
+		backoff time.Duration
+	}{
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{4, 8 * time.Second},
+		{100, time.Minute},
+	}
+
+	for _, test := range tests {
+		if backoff := Backoff(test.attempt); backoff != test.backoff {
+			t.Errorf("Unexpected backoff for attempt %d: %v", test.attempt, backoff)
+		}
+	}
+}
+
+func stateseq(s1, s2 []state) bool {
+	if len(s1) != len(s2) {
+		return false
+	}
+	for i := range s1 {
+		if s1[i] != s2[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func strsleq(s1, s2 []string) bool {
+	if len(s1) != len(s2) {
+		return false
+	}
+	for i := range s1 {
+		if s1[i] != s2[i] {
+			return false
+		}
+	}
+	return true
+}
+
+type attachedMessage struct {
+	message
+	attachments []cchat.MessageAttachment
+}
+
+func (m attachedMessage) AsAttacher() cchat.Attacher { return m }
+
+func (m attachedMessage) Attachments() []cchat.MessageAttachment { return m.attachments }
+
+func (m attachedMessage) AsUploadProgressContainer() cchat.UploadProgressContainer { return nil }
+
+// attachmentSender records the attachment contents that it reads.
+type attachmentSender struct {
+	empty.Sender
+	contents []string
+}
+
+func (s *attachmentSender) CanAttach() bool { return true }
+
+func (s *attachmentSender) Send(_ context.Context, msg cchat.SendableMessage) error {
+	for _, attachment := range msg.AsAttacher().Attachments() {
+		b, err := ioutil.ReadAll(attachment)
+		if err != nil {
+			return err
+		}
+		s.contents = append(s.contents, string(b))
+	}
+	return nil
+}
+
+func TestQueueRetryRewind(t *testing.T) {
+	s := &attachmentSender{}
+	c := &container{states: map[string][]state{}, done: make(chan string, 10)}
+	q := New(s, c)
+	defer q.Close()
+
+	q.Send(attachedMessage{
+		message:     message{nonce: "a"},
+		attachments: []cchat.MessageAttachment{{Reader: strings.NewReader("hello"), Name: "a"}},
+	})
+	<-c.done
+
+	q.SetSendState(context.Background(), "a", cchat.SendStateFailed, errors.New("failed"))
+	<-c.done
+
+	if !q.Retry("a") {
+		t.Fatal("Failed to retry rewindable message")
+	}
+	<-c.done
+
+	if !strsleq(s.contents, []string{"hello", "hello"}) {
+		t.Fatal("Unexpected attachment contents:", s.contents)
+	}
+
+	// Readers that cannot be rewound are not retried.
+	q.Send(attachedMessage{
+		message: message{nonce: "b"},
+		attachments: []cchat.MessageAttachment{
+			{Reader: ioutil.NopCloser(strings.NewReader("world")), Name: "b"},
+		},
+	})
+	<-c.done
+
+	q.SetSendState(context.Background(), "b", cchat.SendStateFailed, errors.New("failed"))
+	<-c.done
+
+	if q.Retry("b") {
+		t.Fatal("Unexpected retry of message that cannot be rewound")
+	}
+}
+
+// blockingSender ignores the context and blocks until release is closed.
+type blockingSender struct {
+	sender
+	entered chan struct{}
+	release chan struct{}
+}
+
+func (s *blockingSender) Send(ctx context.Context, msg cchat.SendableMessage) error {
+	s.entered <- struct{}{}
+	<-s.release
+	return s.sender.Send(ctx, msg)
+}
+
+func TestQueueClose(t *testing.T) {
+	s := &blockingSender{
+		entered: make(chan struct{}, 2),
+		release: make(chan struct{}),
+	}
+	q, c := newQueue(&s.sender)
+	q.sender = s
+
+	q.Send(message{nonce: "a"})
+	q.Send(message{nonce: "b"})
+	<-s.entered
+
+	closed := make(chan struct{})
+	go func() {
+		q.Close()
+		close(closed)
+	}()
+
+	// Let a return only after the queue is closed.
+	<-q.ctx.Done()
+	close(s.release)
+	<-closed
+
+	if !strsleq(s.sent, []string{"a"}) {
+		t.Fatal("Unexpected sent messages after close:", s.sent)
+	}
+
+	if states := c.states["b"]; len(states) == 0 || states[len(states)-1].state != cchat.SendStateFailed {
+		t.Fatal("Unexpected states of drained message:", states)
+	}
+}
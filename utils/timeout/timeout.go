@@ -0,0 +1,290 @@
+// Package timeout provides generated wrappers that enforce deadlines on the
+// blocking methods of cchat interfaces.
+//
+// Usage
+//
+// Wrapping a service wraps everything returned from it as well, including
+// asserted interfaces, so every IO and container method reachable from the
+// service gets a deadline:
+//
+//    service = timeout.Service(service, timeout.New())
+//
+// Services registered into package services can be wrapped with
+// services.SetTimeouts.
+//
+// Each blocking method is called in its own goroutine with a context that has
+// the method's deadline. The wrapper returns an *Error as soon as that context
+// is done, even if the backend ignores it. The abandoned call keeps running in
+// the background and is reported as leaked until it returns. Stop callbacks
+// returned by abandoned container methods are called once they return, and so
+// are disposer methods such as Disconnect on Sessions returned late by
+// Authenticate. Other late results are dropped.
+//
+// Containers given to an abandoned call are abandoned with it, since the
+// frontend may discard them once the call returns. Calls that the backend
+// makes on them afterwards are not passed to the frontend and are reported
+// through OnLeak instead.
+//
+// Methods in Timeouts.Wait are never abandoned. Their contexts still have
+// deadlines, but the wrappers wait for the backend to return. By default, this
+// only includes Sender.Send, since an abandoned Send could keep reading
+// attachments that the frontend closes once Send returns, and a frontend or
+// package sendqueue retrying it could send the message twice.
+package timeout
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultTimeout is the deadline of blocking methods that are not in
+// MethodTimeouts.
+const DefaultTimeout = 30 * time.Second
+
+// MethodTimeouts contains the default deadlines of methods that usually take
+// longer or shorter than DefaultTimeout.
+var MethodTimeouts = map[string]time.Duration{
+	// Authentication may need a few round trips.
+	"cchat.Authenticator.Authenticate": time.Minute,
+	// Sending uploads attachments.
+	"cchat.Sender.Send": 5 * time.Minute,
+	// Disconnecting is mostly cleanup, and the frontend waits for it on exit.
+	"cchat.Session.Disconnect": 10 * time.Second,
+}
+
+// WaitMethods contains the methods that are waited for instead of abandoned by
+// default.
+var WaitMethods = []string{
+	"cchat.Sender.Send",
+}
+
+// Timeouts contains the deadlines of blocking methods. It must not be copied
+// after first use.
+type Timeouts struct {
+	leaked int64 // atomic, must be first for alignment
+
+	// Default is the deadline of methods not in Methods. No deadline is
+	// applied if it is zero or negative, but wrapped methods still return
+	// once their context is canceled.
+	Default time.Duration
+	// Methods maps blocking methods, such as "cchat.Sender.Send", to their
+	// deadlines, which override Default. Refer to BlockingMethods for the
+	// list of keys.
+	Methods map[string]time.Duration
+	// Wait contains the methods that are waited for after their deadlines
+	// instead of being abandoned. Refer to WaitMethods for the default.
+	Wait map[string]bool
+	// OnLeak is called when a method call is abandoned, for every container
+	// call dropped because of it, and again once it returns. It may be called
+	// from multiple goroutines at once. It is optional.
+	OnLeak func(Leak)
+
+	// root and call are only set for the Timeouts that wraps the containers
+	// given to a call, which defers everything else to root.
+	root *Timeouts
+	call *call
+}
+
+// New creates a new Timeouts with DefaultTimeout, a copy of MethodTimeouts
+// and WaitMethods.
+func New() *Timeouts {
+	var methods = make(map[string]time.Duration, len(MethodTimeouts))
+	for method, timeout := range MethodTimeouts {
+		methods[method] = timeout
+	}
+
+	var wait = make(map[string]bool, len(WaitMethods))
+	for _, method := range WaitMethods {
+		wait[method] = true
+	}
+
+	return &Timeouts{
+		Default: DefaultTimeout,
+		Methods: methods,
+		Wait:    wait,
+	}
+}
+
+// Timeout returns the deadline of the given method.
+func (t *Timeouts) Timeout(method string) time.Duration {
+	t = t.base()
+
+	if timeout, ok := t.Methods[method]; ok {
+		return timeout
+	}
+	return t.Default
+}
+
+// Leaked returns the number of abandoned method calls that have not returned
+// yet.
+func (t *Timeouts) Leaked() int {
+	return int(atomic.LoadInt64(&t.base().leaked))
+}
+
+// base returns the Timeouts that t was derived from, or t itself.
+func (t *Timeouts) base() *Timeouts {
+	if t.root != nil {
+		return t.root
+	}
+	return t
+}
+
+// dropped returns true if the containers wrapped with t were given to an
+// abandoned call, in which case the container call is reported as a leak.
+func (t *Timeouts) dropped(method string) bool {
+	if t.call == nil {
+		return false
+	}
+
+	abandoned, _ := t.call.abandoned.Load().(*Error)
+	if abandoned == nil {
+		return false
+	}
+
+	t.call.report(Leak{Err: abandoned.Err, Dropped: method})
+	return true
+}
+
+// Error is returned by a method that is abandoned.
+type Error struct {
+	Method string
+	// Err is context.DeadlineExceeded if the method's deadline was exceeded,
+	// or the error of the caller's context otherwise.
+	Err error
+}
+
+// Error implements error.
+func (err *Error) Error() string {
+	return fmt.Sprintf("%s abandoned: %v", err.Method, err.Err)
+}
+
+// Unwrap returns err.Err.
+func (err *Error) Unwrap() error {
+	return err.Err
+}
+
+// Leak describes an abandoned method call.
+type Leak struct {
+	Method string
+	Err    error
+	// Elapsed is the time since the method was called.
+	Elapsed time.Duration
+	// Returned is false when the call is abandoned and true once the backend
+	// returns.
+	Returned bool
+	// Dropped is the container method, such as
+	// "cchat.MessagesContainer.CreateMessage", that the abandoned call called
+	// without it being passed to the frontend. It is empty otherwise.
+	Dropped string
+}
+
+// String formats the leak into a single line.
+func (l Leak) String() string {
+	if l.Dropped != "" {
+		return fmt.Sprintf("%s dropped from abandoned %s [%v]", l.Dropped, l.Method, l.Elapsed)
+	}
+	if l.Returned {
+		return fmt.Sprintf("%s returned after being abandoned [%v]", l.Method, l.Elapsed)
+	}
+	return fmt.Sprintf("%s abandoned: %v [%v]", l.Method, l.Err, l.Elapsed)
+}
+
+// call is a blocking method call in progress.
+type call struct {
+	t      *Timeouts
+	method string
+	start  time.Time
+	ctx    context.Context
+	cancel context.CancelFunc
+	wait   bool
+	// abandoned is set to the *Error returned by run if the call is
+	// abandoned.
+	abandoned atomic.Value
+}
+
+// begin derives the context that the method is called with.
+func (t *Timeouts) begin(ctx context.Context, method string) (context.Context, *call) {
+	t = t.base()
+
+	c := &call{
+		t:      t,
+		method: method,
+		start:  time.Now(),
+		wait:   t.Wait[method],
+	}
+
+	if timeout := t.Timeout(method); timeout > 0 {
+		c.ctx, c.cancel = context.WithTimeout(ctx, timeout)
+	} else {
+		c.ctx, c.cancel = context.WithCancel(ctx)
+	}
+
+	return c.ctx, c
+}
+
+// scope returns the Timeouts for the containers given to the call.
+func (c *call) scope() *Timeouts {
+	return &Timeouts{root: c.t, call: c}
+}
+
+// run calls f in another goroutine and waits for it to return. If the context
+// is done first, an *Error is returned without waiting, and late is called
+// after f returns if it's not nil. If the method is waited for, f is called
+// directly instead.
+func (c *call) run(f, late func()) error {
+	defer c.cancel()
+
+	if c.wait {
+		f()
+		return nil
+	}
+
+	done := make(chan struct{})
+	go func() {
+		f()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-c.ctx.Done():
+	}
+
+	// Prefer the result if f returned at the same time.
+	select {
+	case <-done:
+		return nil
+	default:
+	}
+
+	abandoned := &Error{Method: c.method, Err: c.ctx.Err()}
+	c.abandoned.Store(abandoned)
+
+	atomic.AddInt64(&c.t.leaked, 1)
+	c.report(Leak{Err: abandoned.Err})
+
+	go func() {
+		<-done
+		if late != nil {
+			late()
+		}
+		atomic.AddInt64(&c.t.leaked, -1)
+		c.report(Leak{Err: abandoned.Err, Returned: true})
+	}()
+
+	return abandoned
+}
+
+// report fills in the method and elapsed time of the leak and reports it.
+func (c *call) report(leak Leak) {
+	if c.t.OnLeak == nil {
+		return
+	}
+
+	leak.Method = c.method
+	leak.Elapsed = time.Since(c.start)
+	c.t.OnLeak(leak)
+}
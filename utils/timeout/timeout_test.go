@@ -0,0 +1,345 @@
+package timeout
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/diamondburned/cchat"
+	"github.com/diamondburned/cchat/text"
+	"github.com/diamondburned/cchat/utils/empty"
+)
+
+// testSender ignores the context and blocks until release is closed.
+type testSender struct {
+	empty.Sender
+	release chan struct{}
+}
+
+func (testSender) CanAttach() bool { return false }
+
+func (s testSender) Send(context.Context, cchat.SendableMessage) error {
+	<-s.release
+	return nil
+}
+
+// testServer returns a stop callback after release is closed.
+type testServer struct {
+	empty.Server
+	release chan struct{}
+	stopped chan struct{}
+}
+
+func (testServer) ID() cchat.ID { return "1" }
+
+func (s testServer) Name(context.Context, cchat.LabelContainer) (func(), error) {
+	<-s.release
+	return func() { close(s.stopped) }, nil
+}
+
+type leakRecorder struct {
+	mu    sync.Mutex
+	leaks []Leak
+}
+
+func (r *leakRecorder) onLeak(l Leak) {
+	r.mu.Lock()
+	r.leaks = append(r.leaks, l)
+	r.mu.Unlock()
+}
+
+func (r *leakRecorder) returned() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.leaks) == 2 && r.leaks[1].Returned
+}
+
+func waitUntil(t *testing.T, cond func() bool) {
+	t.Helper()
+
+	for deadline := time.Now().Add(5 * time.Second); !cond(); {
+		if time.Now().After(deadline) {
+			t.Fatal("Timed out waiting for condition.")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestDeadline(t *testing.T) {
+	r := &leakRecorder{}
+	timeouts := &Timeouts{
+		Methods: map[string]time.Duration{"cchat.Sender.Send": 10 * time.Millisecond},
+		OnLeak:  r.onLeak,
+	}
+
+	backend := testSender{release: make(chan struct{})}
+	sender := Sender(backend, timeouts)
+
+	err := sender.Send(context.Background(), nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	var timeoutErr *Error
+	if !errors.As(err, &timeoutErr) || timeoutErr.Method != "cchat.Sender.Send" {
+		t.Fatalf("Unexpected error %#v", err)
+	}
+
+	if leaked := timeouts.Leaked(); leaked != 1 {
+		t.Fatal("Unexpected leaked count:", leaked)
+	}
+
+	close(backend.release)
+	waitUntil(t, r.returned)
+
+	if leaked := timeouts.Leaked(); leaked != 0 {
+		t.Fatal("Unexpected leaked count after return:", leaked)
+	}
+}
+
+func TestCancel(t *testing.T) {
+	// No deadline, so only the caller's context can abandon the call.
+	timeouts := &Timeouts{}
+
+	backend := testSender{release: make(chan struct{})}
+	defer close(backend.release)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+
+	err := Sender(backend, timeouts).Send(ctx, nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatal("Unexpected error:", err)
+	}
+}
+
+func TestReturn(t *testing.T) {
+	backend := testSender{release: make(chan struct{})}
+	close(backend.release)
+
+	timeouts := New()
+
+	if err := Sender(backend, timeouts).Send(context.Background(), nil); err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	if leaked := timeouts.Leaked(); leaked != 0 {
+		t.Fatal("Unexpected leaked count:", leaked)
+	}
+}
+
+func TestContainerStop(t *testing.T) {
+	r := &leakRecorder{}
+	timeouts := &Timeouts{
+		Default: 10 * time.Millisecond,
+		OnLeak:  r.onLeak,
+	}
+
+	backend := testServer{
+		release: make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+
+	stop, err := Server(backend, timeouts).Name(context.Background(), testLabel{})
+	if stop != nil || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatal("Unexpected Name results:", stop != nil, err)
+	}
+
+	close(backend.release)
+
+	// The late stop callback must be called for the backend to clean up.
+	select {
+	case <-backend.stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for stop callback.")
+	}
+
+	waitUntil(t, r.returned)
+
+	if method := r.leaks[0].Method; method != "cchat.Namer.Name" {
+		t.Fatal("Unexpected leaked method:", method)
+	}
+}
+
+type testAuthenticator struct{}
+
+func (testAuthenticator) AuthenticateForm() []cchat.AuthenticateEntry { return nil }
+
+func (testAuthenticator) Authenticate(ctx context.Context, _ []string) (cchat.Session, cchat.AuthenticateError) {
+	<-ctx.Done()
+	return nil, cchat.WrapAuthenticateError(ctx.Err())
+}
+
+func (testAuthenticator) Description() text.Rich { return text.Plain("") }
+
+func (testAuthenticator) Name() text.Rich { return text.Plain("") }
+
+func TestAuthenticate(t *testing.T) {
+	timeouts := &Timeouts{Default: 10 * time.Millisecond}
+
+	_, err := Authenticator(testAuthenticator{}, timeouts).Authenticate(context.Background(), nil)
+	// Either the backend or the wrapper may return first.
+	if err == nil || !strings.Contains(err.Error(), context.DeadlineExceeded.Error()) {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	if err.NextStage() != nil {
+		t.Fatal("Unexpected next stage.")
+	}
+}
+
+// testSessionRestorer returns a session after release is closed.
+type testSessionRestorer struct {
+	release      chan struct{}
+	disconnected chan struct{}
+}
+
+func (r testSessionRestorer) RestoreSession(context.Context, map[string]string) (cchat.Session, error) {
+	<-r.release
+	return testSession{disconnected: r.disconnected}, nil
+}
+
+type testSession struct {
+	empty.Session
+	disconnected chan struct{}
+}
+
+func (testSession) ID() cchat.ID { return "1" }
+
+func (testSession) Name(context.Context, cchat.LabelContainer) (func(), error) { return nil, nil }
+
+func (testSession) Servers(cchat.ServersContainer) (func(), error) { return nil, nil }
+
+func (testSession) Columnate() bool { return false }
+
+func (s testSession) Disconnect(context.Context) error {
+	close(s.disconnected)
+	return nil
+}
+
+func TestSessionDisconnect(t *testing.T) {
+	timeouts := &Timeouts{Default: 10 * time.Millisecond}
+
+	backend := testSessionRestorer{
+		release:      make(chan struct{}),
+		disconnected: make(chan struct{}),
+	}
+
+	session, err := SessionRestorer(backend, timeouts).RestoreSession(context.Background(), nil)
+	if session != nil || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatal("Unexpected RestoreSession results:", session != nil, err)
+	}
+
+	close(backend.release)
+
+	// The session returned late must be disconnected, since the frontend never
+	// gets it.
+	select {
+	case <-backend.disconnected:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for Disconnect.")
+	}
+}
+
+// testContextSender returns once the context is done.
+type testContextSender struct {
+	empty.Sender
+}
+
+func (testContextSender) CanAttach() bool { return false }
+
+func (testContextSender) Send(ctx context.Context, _ cchat.SendableMessage) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestWait(t *testing.T) {
+	timeouts := New()
+	timeouts.Methods["cchat.Sender.Send"] = 10 * time.Millisecond
+
+	err := Sender(testContextSender{}, timeouts).Send(context.Background(), nil)
+	if err != context.DeadlineExceeded {
+		t.Fatal("Unexpected error from waited Send:", err)
+	}
+
+	if leaked := timeouts.Leaked(); leaked != 0 {
+		t.Fatal("Unexpected leaked count:", leaked)
+	}
+}
+
+type testLabel struct{}
+
+func (testLabel) SetLabel(context.Context, text.Rich) {}
+
+// testProfiler ignores the context and sets the avatar once release is closed.
+type testProfiler struct {
+	release chan struct{}
+	set     chan struct{}
+}
+
+func (p testProfiler) Profile(ctx context.Context, _ cchat.ID, c cchat.ProfileContainer) error {
+	<-p.release
+	c.SetAvatar(ctx, "avatar")
+	close(p.set)
+	return nil
+}
+
+type testProfile struct {
+	avatars chan string
+}
+
+func (p testProfile) SetAvatar(_ context.Context, url string) { p.avatars <- url }
+
+func (testProfile) SetMutualServers(context.Context, []cchat.Server)   {}
+func (testProfile) SetRoles(context.Context, []cchat.Role)             {}
+func (testProfile) SetStatus(context.Context, cchat.Status, text.Rich) {}
+func (testProfile) SetBio(context.Context, text.Rich)                  {}
+func (testProfile) SetDisplayName(context.Context, text.Rich)          {}
+
+func TestContainerDropped(t *testing.T) {
+	r := &leakRecorder{}
+	timeouts := &Timeouts{
+		Default: 10 * time.Millisecond,
+		OnLeak:  r.onLeak,
+	}
+
+	frontend := testProfile{avatars: make(chan string, 1)}
+
+	// Containers of calls that return in time are passed through.
+	backend := testProfiler{release: make(chan struct{}), set: make(chan struct{})}
+	close(backend.release)
+
+	if err := Profiler(backend, timeouts).Profile(context.Background(), "1", frontend); err != nil {
+		t.Fatal("Unexpected Profile error:", err)
+	}
+	if url := <-frontend.avatars; url != "avatar" {
+		t.Fatal("Unexpected avatar:", url)
+	}
+
+	// Containers of abandoned calls are dropped.
+	backend = testProfiler{release: make(chan struct{}), set: make(chan struct{})}
+
+	err := Profiler(backend, timeouts).Profile(context.Background(), "1", frontend)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatal("Unexpected Profile error:", err)
+	}
+
+	close(backend.release)
+	<-backend.set
+
+	select {
+	case url := <-frontend.avatars:
+		t.Fatal("Abandoned Profile set the avatar:", url)
+	default:
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.leaks) < 2 || r.leaks[1].Dropped != "cchat.ProfileContainer.SetAvatar" {
+		t.Fatal("Unexpected leaks:", r.leaks)
+	}
+}
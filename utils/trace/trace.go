@@ -0,0 +1,154 @@
+// Package trace provides generated wrappers that trace every method call made
+// on cchat interfaces, which is useful for debugging backends.
+//
+// Usage
+//
+// Wrapping a service wraps everything returned from it as well, including
+// asserted interfaces. Containers given by the frontend are also wrapped, so
+// their methods that the backend calls are traced, and so are the stop
+// callbacks returned by container methods.
+//
+//    logger := log.New(os.Stderr, "cchat: ", log.LstdFlags)
+//    service = trace.Service(service, trace.NewLogger(logger))
+//
+// Services registered into package services can be traced with
+// services.SetTracer.
+//
+// Asserter calls are not traced, since they are called often and must not do
+// IO anyway. Values that may contain credentials, such as authentication
+// inputs and session data, are replaced with Redacted.
+package trace
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+// Redacted replaces values that may contain credentials in events.
+var Redacted interface{} = redacted{}
+
+type redacted struct{}
+
+func (redacted) String() string { return "<redacted>" }
+
+// Event is a traced method call.
+type Event struct {
+	// Interface is the Go type name of the interface, such as "cchat.Server".
+	Interface string
+	Method    string
+	// Args contains the method's arguments, excluding the context.
+	Args []interface{}
+	// Returns contains the method's return values. Stop callbacks are
+	// excluded, since their calls are traced separately.
+	Returns []interface{}
+	// Duration is the time taken for blocking methods to return. It is zero
+	// for other methods.
+	Duration time.Duration
+	// Stop is true if the event is for calling the stop callback returned by
+	// Method.
+	Stop bool
+}
+
+// String formats the event into a single line.
+func (ev Event) String() string {
+	var builder strings.Builder
+
+	builder.WriteString(ev.Interface)
+	builder.WriteByte('.')
+	builder.WriteString(ev.Method)
+
+	if ev.Stop {
+		builder.WriteString(" stop()")
+	} else {
+		builder.WriteByte('(')
+		writeValues(&builder, ev.Args)
+		builder.WriteByte(')')
+	}
+
+	if len(ev.Returns) > 0 {
+		builder.WriteString(" = ")
+		writeValues(&builder, ev.Returns)
+	}
+
+	if ev.Duration > 0 {
+		fmt.Fprintf(&builder, " [%v]", ev.Duration)
+	}
+
+	return builder.String()
+}
+
+func writeValues(builder *strings.Builder, values []interface{}) {
+	for i, v := range values {
+		if i > 0 {
+			builder.WriteString(", ")
+		}
+		fmt.Fprintf(builder, "%v", v)
+	}
+}
+
+// Tracer is called after each traced method call. It may be called from
+// multiple goroutines at once.
+type Tracer interface {
+	Trace(Event)
+}
+
+// TracerFunc is a function that implements Tracer.
+type TracerFunc func(Event)
+
+// Trace calls f.
+func (f TracerFunc) Trace(ev Event) { f(ev) }
+
+// NewLogger returns a Tracer that prints every event into the given logger.
+func NewLogger(l *log.Logger) Tracer {
+	return TracerFunc(func(ev Event) { l.Println(ev) })
+}
+
+// call is a method call in progress.
+type call struct {
+	t     Tracer
+	ev    Event
+	start time.Time
+}
+
+func begin(t Tracer, iface, method string, args ...interface{}) call {
+	return call{
+		t: t,
+		ev: Event{
+			Interface: iface,
+			Method:    method,
+			Args:      args,
+		},
+	}
+}
+
+// beginBlocking is begin, except the duration is measured.
+func beginBlocking(t Tracer, iface, method string, args ...interface{}) call {
+	c := begin(t, iface, method, args...)
+	c.start = time.Now()
+	return c
+}
+
+func (c call) end(returns ...interface{}) {
+	if !c.start.IsZero() {
+		c.ev.Duration = time.Since(c.start)
+	}
+	c.ev.Returns = returns
+	c.t.Trace(c.ev)
+}
+
+// stopFunc wraps the stop callback returned by the given method to trace it.
+// Nil is returned if stop is nil.
+func stopFunc(t Tracer, iface, method string, stop func()) func() {
+	if stop == nil {
+		return nil
+	}
+
+	return func() {
+		c := begin(t, iface, method)
+		c.ev.Stop = true
+		stop()
+		c.end()
+	}
+}
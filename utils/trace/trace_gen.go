@@ -0,0 +1,2164 @@
+// Code generated by ./cmd/internal. DO NOT EDIT.
+
+package trace
+
+import (
+	"context"
+	"github.com/diamondburned/cchat"
+	"github.com/diamondburned/cchat/text"
+	"time"
+)
+
+type identifier struct {
+	v cchat.Identifier
+	t Tracer
+}
+
+// Identifier wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func Identifier(v cchat.Identifier, t Tracer) cchat.Identifier {
+	if v == nil {
+		return nil
+	}
+	return identifier{v, t}
+}
+
+func (w identifier) ID() cchat.ID {
+	c := begin(w.t, "cchat.Identifier", "ID")
+	r0 := w.v.ID()
+	c.end(r0)
+	return r0
+}
+
+type namer struct {
+	v cchat.Namer
+	t Tracer
+}
+
+// Namer wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func Namer(v cchat.Namer, t Tracer) cchat.Namer {
+	if v == nil {
+		return nil
+	}
+	return namer{v, t}
+}
+
+func (w namer) Name(ctx context.Context, a0 cchat.LabelContainer) (func(), error) {
+	c := beginBlocking(w.t, "cchat.Namer", "Name", a0)
+	stop, err := w.v.Name(ctx, LabelContainer(a0, w.t))
+	c.end(err)
+	return stopFunc(w.t, "cchat.Namer", "Name", stop), err
+}
+
+type noncer struct {
+	v cchat.Noncer
+	t Tracer
+}
+
+// Noncer wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func Noncer(v cchat.Noncer, t Tracer) cchat.Noncer {
+	if v == nil {
+		return nil
+	}
+	return noncer{v, t}
+}
+
+func (w noncer) Nonce() string {
+	c := begin(w.t, "cchat.Noncer", "Nonce")
+	r0 := w.v.Nonce()
+	c.end(r0)
+	return r0
+}
+
+type user struct {
+	v cchat.User
+	t Tracer
+}
+
+// User wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func User(v cchat.User, t Tracer) cchat.User {
+	if v == nil {
+		return nil
+	}
+	return user{v, t}
+}
+
+func (w user) ID() cchat.ID {
+	c := begin(w.t, "cchat.User", "ID")
+	r0 := w.v.ID()
+	c.end(r0)
+	return r0
+}
+
+func (w user) Name(ctx context.Context, a0 cchat.LabelContainer) (func(), error) {
+	c := beginBlocking(w.t, "cchat.User", "Name", a0)
+	stop, err := w.v.Name(ctx, LabelContainer(a0, w.t))
+	c.end(err)
+	return stopFunc(w.t, "cchat.User", "Name", stop), err
+}
+
+type service struct {
+	v cchat.Service
+	t Tracer
+}
+
+// Service wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func Service(v cchat.Service, t Tracer) cchat.Service {
+	if v == nil {
+		return nil
+	}
+	return service{v, t}
+}
+
+func (w service) ID() cchat.ID {
+	c := begin(w.t, "cchat.Service", "ID")
+	r0 := w.v.ID()
+	c.end(r0)
+	return r0
+}
+
+func (w service) Name(ctx context.Context, a0 cchat.LabelContainer) (func(), error) {
+	c := beginBlocking(w.t, "cchat.Service", "Name", a0)
+	stop, err := w.v.Name(ctx, LabelContainer(a0, w.t))
+	c.end(err)
+	return stopFunc(w.t, "cchat.Service", "Name", stop), err
+}
+
+func (w service) Authenticate() []cchat.Authenticator {
+	c := begin(w.t, "cchat.Service", "Authenticate")
+	r0 := w.v.Authenticate()
+	c.end(r0)
+	return authenticatorSlice(r0, w.t)
+}
+
+func (w service) AsConfigurator() cchat.Configurator {
+	return Configurator(w.v.AsConfigurator(), w.t)
+}
+
+func (w service) AsSessionRestorer() cchat.SessionRestorer {
+	return SessionRestorer(w.v.AsSessionRestorer(), w.t)
+}
+
+type authenticateError struct {
+	v cchat.AuthenticateError
+	t Tracer
+}
+
+// AuthenticateError wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func AuthenticateError(v cchat.AuthenticateError, t Tracer) cchat.AuthenticateError {
+	if v == nil {
+		return nil
+	}
+	return authenticateError{v, t}
+}
+
+func (w authenticateError) Error() string {
+	c := begin(w.t, "cchat.AuthenticateError", "Error")
+	r0 := w.v.Error()
+	c.end(r0)
+	return r0
+}
+
+func (w authenticateError) NextStage() []cchat.Authenticator {
+	c := begin(w.t, "cchat.AuthenticateError", "NextStage")
+	r0 := w.v.NextStage()
+	c.end(r0)
+	return authenticatorSlice(r0, w.t)
+}
+
+type authenticator struct {
+	v cchat.Authenticator
+	t Tracer
+}
+
+// Authenticator wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func Authenticator(v cchat.Authenticator, t Tracer) cchat.Authenticator {
+	if v == nil {
+		return nil
+	}
+	return authenticator{v, t}
+}
+
+func (w authenticator) Name() text.Rich {
+	c := begin(w.t, "cchat.Authenticator", "Name")
+	r0 := w.v.Name()
+	c.end(r0)
+	return r0
+}
+
+func (w authenticator) Description() text.Rich {
+	c := begin(w.t, "cchat.Authenticator", "Description")
+	r0 := w.v.Description()
+	c.end(r0)
+	return r0
+}
+
+func (w authenticator) AuthenticateForm() []cchat.AuthenticateEntry {
+	c := begin(w.t, "cchat.Authenticator", "AuthenticateForm")
+	r0 := w.v.AuthenticateForm()
+	c.end(r0)
+	return r0
+}
+
+func (w authenticator) Authenticate(ctx context.Context, a0 []string) (cchat.Session, cchat.AuthenticateError) {
+	c := beginBlocking(w.t, "cchat.Authenticator", "Authenticate", Redacted)
+	r0, err := w.v.Authenticate(ctx, a0)
+	c.end(Redacted, err)
+	return Session(r0, w.t), err
+}
+
+type sessionRestorer struct {
+	v cchat.SessionRestorer
+	t Tracer
+}
+
+// SessionRestorer wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func SessionRestorer(v cchat.SessionRestorer, t Tracer) cchat.SessionRestorer {
+	if v == nil {
+		return nil
+	}
+	return sessionRestorer{v, t}
+}
+
+func (w sessionRestorer) RestoreSession(ctx context.Context, a0 map[string]string) (cchat.Session, error) {
+	c := beginBlocking(w.t, "cchat.SessionRestorer", "RestoreSession", Redacted)
+	r0, err := w.v.RestoreSession(ctx, a0)
+	c.end(Redacted, err)
+	return Session(r0, w.t), err
+}
+
+type configurator struct {
+	v cchat.Configurator
+	t Tracer
+}
+
+// Configurator wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func Configurator(v cchat.Configurator, t Tracer) cchat.Configurator {
+	if v == nil {
+		return nil
+	}
+	return configurator{v, t}
+}
+
+func (w configurator) Configuration() map[string]string {
+	c := begin(w.t, "cchat.Configurator", "Configuration")
+	r0 := w.v.Configuration()
+	c.end(Redacted)
+	return r0
+}
+
+func (w configurator) SetConfiguration(a0 map[string]string) error {
+	c := begin(w.t, "cchat.Configurator", "SetConfiguration", Redacted)
+	err := w.v.SetConfiguration(a0)
+	c.end(err)
+	return err
+}
+
+type session struct {
+	v cchat.Session
+	t Tracer
+}
+
+// Session wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func Session(v cchat.Session, t Tracer) cchat.Session {
+	if v == nil {
+		return nil
+	}
+	return session{v, t}
+}
+
+func (w session) ID() cchat.ID {
+	c := begin(w.t, "cchat.Session", "ID")
+	r0 := w.v.ID()
+	c.end(r0)
+	return r0
+}
+
+func (w session) Name(ctx context.Context, a0 cchat.LabelContainer) (func(), error) {
+	c := beginBlocking(w.t, "cchat.Session", "Name", a0)
+	stop, err := w.v.Name(ctx, LabelContainer(a0, w.t))
+	c.end(err)
+	return stopFunc(w.t, "cchat.Session", "Name", stop), err
+}
+
+func (w session) Columnate() bool {
+	c := begin(w.t, "cchat.Session", "Columnate")
+	r0 := w.v.Columnate()
+	c.end(r0)
+	return r0
+}
+
+func (w session) Servers(a0 cchat.ServersContainer) (func(), error) {
+	c := beginBlocking(w.t, "cchat.Session", "Servers", a0)
+	stop, err := w.v.Servers(ServersContainer(a0, w.t))
+	c.end(err)
+	return stopFunc(w.t, "cchat.Session", "Servers", stop), err
+}
+
+func (w session) Disconnect(ctx context.Context) error {
+	c := beginBlocking(w.t, "cchat.Session", "Disconnect")
+	err := w.v.Disconnect(ctx)
+	c.end(err)
+	return err
+}
+
+func (w session) AsCommander() cchat.Commander {
+	return Commander(w.v.AsCommander(), w.t)
+}
+
+func (w session) AsSessionSaver() cchat.SessionSaver {
+	return SessionSaver(w.v.AsSessionSaver(), w.t)
+}
+
+func (w session) AsPresenceSetter() cchat.PresenceSetter {
+	return PresenceSetter(w.v.AsPresenceSetter(), w.t)
+}
+
+func (w session) AsProfiler() cchat.Profiler {
+	return Profiler(w.v.AsProfiler(), w.t)
+}
+
+func (w session) AsDirectMessager() cchat.DirectMessager {
+	return DirectMessager(w.v.AsDirectMessager(), w.t)
+}
+
+func (w session) AsEmojier() cchat.Emojier {
+	return Emojier(w.v.AsEmojier(), w.t)
+}
+
+func (w session) AsConnectionStater() cchat.ConnectionStater {
+	return ConnectionStater(w.v.AsConnectionStater(), w.t)
+}
+
+type connectionStater struct {
+	v cchat.ConnectionStater
+	t Tracer
+}
+
+// ConnectionStater wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func ConnectionStater(v cchat.ConnectionStater, t Tracer) cchat.ConnectionStater {
+	if v == nil {
+		return nil
+	}
+	return connectionStater{v, t}
+}
+
+func (w connectionStater) ConnectionSubscribe(ctx context.Context, a0 cchat.ConnectionStateContainer) (func(), error) {
+	c := beginBlocking(w.t, "cchat.ConnectionStater", "ConnectionSubscribe", a0)
+	stop, err := w.v.ConnectionSubscribe(ctx, ConnectionStateContainer(a0, w.t))
+	c.end(err)
+	return stopFunc(w.t, "cchat.ConnectionStater", "ConnectionSubscribe", stop), err
+}
+
+type directMessager struct {
+	v cchat.DirectMessager
+	t Tracer
+}
+
+// DirectMessager wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func DirectMessager(v cchat.DirectMessager, t Tracer) cchat.DirectMessager {
+	if v == nil {
+		return nil
+	}
+	return directMessager{v, t}
+}
+
+func (w directMessager) DirectMessage(ctx context.Context, a0 []cchat.ID) (cchat.Server, error) {
+	c := beginBlocking(w.t, "cchat.DirectMessager", "DirectMessage", a0)
+	r0, err := w.v.DirectMessage(ctx, a0)
+	c.end(r0, err)
+	return Server(r0, w.t), err
+}
+
+type presenceSetter struct {
+	v cchat.PresenceSetter
+	t Tracer
+}
+
+// PresenceSetter wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func PresenceSetter(v cchat.PresenceSetter, t Tracer) cchat.PresenceSetter {
+	if v == nil {
+		return nil
+	}
+	return presenceSetter{v, t}
+}
+
+func (w presenceSetter) SetPresence(ctx context.Context, a0 cchat.Presence) error {
+	c := beginBlocking(w.t, "cchat.PresenceSetter", "SetPresence", a0)
+	err := w.v.SetPresence(ctx, a0)
+	c.end(err)
+	return err
+}
+
+func (w presenceSetter) PresenceSubscribe(ctx context.Context, a0 cchat.PresenceContainer) (func(), error) {
+	c := beginBlocking(w.t, "cchat.PresenceSetter", "PresenceSubscribe", a0)
+	stop, err := w.v.PresenceSubscribe(ctx, PresenceContainer(a0, w.t))
+	c.end(err)
+	return stopFunc(w.t, "cchat.PresenceSetter", "PresenceSubscribe", stop), err
+}
+
+type sessionSaver struct {
+	v cchat.SessionSaver
+	t Tracer
+}
+
+// SessionSaver wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func SessionSaver(v cchat.SessionSaver, t Tracer) cchat.SessionSaver {
+	if v == nil {
+		return nil
+	}
+	return sessionSaver{v, t}
+}
+
+func (w sessionSaver) SaveSession() map[string]string {
+	c := begin(w.t, "cchat.SessionSaver", "SaveSession")
+	r0 := w.v.SaveSession()
+	c.end(Redacted)
+	return r0
+}
+
+type commander struct {
+	v cchat.Commander
+	t Tracer
+}
+
+// Commander wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func Commander(v cchat.Commander, t Tracer) cchat.Commander {
+	if v == nil {
+		return nil
+	}
+	return commander{v, t}
+}
+
+func (w commander) Run(ctx context.Context, a0 []string) ([]byte, error) {
+	c := beginBlocking(w.t, "cchat.Commander", "Run", a0)
+	r0, err := w.v.Run(ctx, a0)
+	c.end(r0, err)
+	return r0, err
+}
+
+func (w commander) AsCompleter() cchat.Completer {
+	return Completer(w.v.AsCompleter(), w.t)
+}
+
+type server struct {
+	v cchat.Server
+	t Tracer
+}
+
+// Server wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func Server(v cchat.Server, t Tracer) cchat.Server {
+	if v == nil {
+		return nil
+	}
+	return server{v, t}
+}
+
+func (w server) ID() cchat.ID {
+	c := begin(w.t, "cchat.Server", "ID")
+	r0 := w.v.ID()
+	c.end(r0)
+	return r0
+}
+
+func (w server) Name(ctx context.Context, a0 cchat.LabelContainer) (func(), error) {
+	c := beginBlocking(w.t, "cchat.Server", "Name", a0)
+	stop, err := w.v.Name(ctx, LabelContainer(a0, w.t))
+	c.end(err)
+	return stopFunc(w.t, "cchat.Server", "Name", stop), err
+}
+
+func (w server) AsLister() cchat.Lister {
+	return Lister(w.v.AsLister(), w.t)
+}
+
+func (w server) AsMessenger() cchat.Messenger {
+	return Messenger(w.v.AsMessenger(), w.t)
+}
+
+func (w server) AsCommander() cchat.Commander {
+	return Commander(w.v.AsCommander(), w.t)
+}
+
+func (w server) AsConfigurator() cchat.Configurator {
+	return Configurator(w.v.AsConfigurator(), w.t)
+}
+
+func (w server) AsNotificationSettings() cchat.NotificationSettings {
+	return NotificationSettings(w.v.AsNotificationSettings(), w.t)
+}
+
+type notificationSettings struct {
+	v cchat.NotificationSettings
+	t Tracer
+}
+
+// NotificationSettings wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func NotificationSettings(v cchat.NotificationSettings, t Tracer) cchat.NotificationSettings {
+	if v == nil {
+		return nil
+	}
+	return notificationSettings{v, t}
+}
+
+func (w notificationSettings) NotificationPreference() cchat.NotificationPreference {
+	c := begin(w.t, "cchat.NotificationSettings", "NotificationPreference")
+	r0 := w.v.NotificationPreference()
+	c.end(r0)
+	return r0
+}
+
+func (w notificationSettings) SetNotificationPreference(ctx context.Context, a0 cchat.NotificationPreference) error {
+	c := beginBlocking(w.t, "cchat.NotificationSettings", "SetNotificationPreference", a0)
+	err := w.v.SetNotificationPreference(ctx, a0)
+	c.end(err)
+	return err
+}
+
+type lister struct {
+	v cchat.Lister
+	t Tracer
+}
+
+// Lister wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func Lister(v cchat.Lister, t Tracer) cchat.Lister {
+	if v == nil {
+		return nil
+	}
+	return lister{v, t}
+}
+
+func (w lister) Columnate() bool {
+	c := begin(w.t, "cchat.Lister", "Columnate")
+	r0 := w.v.Columnate()
+	c.end(r0)
+	return r0
+}
+
+func (w lister) Servers(a0 cchat.ServersContainer) (func(), error) {
+	c := beginBlocking(w.t, "cchat.Lister", "Servers", a0)
+	stop, err := w.v.Servers(ServersContainer(a0, w.t))
+	c.end(err)
+	return stopFunc(w.t, "cchat.Lister", "Servers", stop), err
+}
+
+type messenger struct {
+	v cchat.Messenger
+	t Tracer
+}
+
+// Messenger wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func Messenger(v cchat.Messenger, t Tracer) cchat.Messenger {
+	if v == nil {
+		return nil
+	}
+	return messenger{v, t}
+}
+
+func (w messenger) JoinServer(ctx context.Context, a0 cchat.MessagesContainer) (func(), error) {
+	c := beginBlocking(w.t, "cchat.Messenger", "JoinServer", a0)
+	stop, err := w.v.JoinServer(ctx, MessagesContainer(a0, w.t))
+	c.end(err)
+	return stopFunc(w.t, "cchat.Messenger", "JoinServer", stop), err
+}
+
+func (w messenger) AsSender() cchat.Sender {
+	return Sender(w.v.AsSender(), w.t)
+}
+
+func (w messenger) AsEditor() cchat.Editor {
+	return Editor(w.v.AsEditor(), w.t)
+}
+
+func (w messenger) AsDeleter() cchat.Deleter {
+	return Deleter(w.v.AsDeleter(), w.t)
+}
+
+func (w messenger) AsActioner() cchat.Actioner {
+	return Actioner(w.v.AsActioner(), w.t)
+}
+
+func (w messenger) AsNicknamer() cchat.Nicknamer {
+	return Nicknamer(w.v.AsNicknamer(), w.t)
+}
+
+func (w messenger) AsBacklogger() cchat.Backlogger {
+	return Backlogger(w.v.AsBacklogger(), w.t)
+}
+
+func (w messenger) AsMemberLister() cchat.MemberLister {
+	return MemberLister(w.v.AsMemberLister(), w.t)
+}
+
+func (w messenger) AsReadIndicator() cchat.ReadIndicator {
+	return ReadIndicator(w.v.AsReadIndicator(), w.t)
+}
+
+func (w messenger) AsUnreadIndicator() cchat.UnreadIndicator {
+	return UnreadIndicator(w.v.AsUnreadIndicator(), w.t)
+}
+
+func (w messenger) AsTypingIndicator() cchat.TypingIndicator {
+	return TypingIndicator(w.v.AsTypingIndicator(), w.t)
+}
+
+func (w messenger) AsProfiler() cchat.Profiler {
+	return Profiler(w.v.AsProfiler(), w.t)
+}
+
+func (w messenger) AsPinner() cchat.Pinner {
+	return Pinner(w.v.AsPinner(), w.t)
+}
+
+func (w messenger) AsEmojier() cchat.Emojier {
+	return Emojier(w.v.AsEmojier(), w.t)
+}
+
+func (w messenger) AsDraftSyncer() cchat.DraftSyncer {
+	return DraftSyncer(w.v.AsDraftSyncer(), w.t)
+}
+
+type draftSyncer struct {
+	v cchat.DraftSyncer
+	t Tracer
+}
+
+// DraftSyncer wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func DraftSyncer(v cchat.DraftSyncer, t Tracer) cchat.DraftSyncer {
+	if v == nil {
+		return nil
+	}
+	return draftSyncer{v, t}
+}
+
+func (w draftSyncer) Draft(ctx context.Context) (cchat.Draft, error) {
+	c := beginBlocking(w.t, "cchat.DraftSyncer", "Draft")
+	r0, err := w.v.Draft(ctx)
+	c.end(r0, err)
+	return r0, err
+}
+
+func (w draftSyncer) SetDraft(ctx context.Context, a0 cchat.Draft) error {
+	c := beginBlocking(w.t, "cchat.DraftSyncer", "SetDraft", a0)
+	err := w.v.SetDraft(ctx, a0)
+	c.end(err)
+	return err
+}
+
+type emojier struct {
+	v cchat.Emojier
+	t Tracer
+}
+
+// Emojier wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func Emojier(v cchat.Emojier, t Tracer) cchat.Emojier {
+	if v == nil {
+		return nil
+	}
+	return emojier{v, t}
+}
+
+func (w emojier) Emojis(ctx context.Context) ([]cchat.EmojiGroup, error) {
+	c := beginBlocking(w.t, "cchat.Emojier", "Emojis")
+	r0, err := w.v.Emojis(ctx)
+	c.end(r0, err)
+	return r0, err
+}
+
+type sender struct {
+	v cchat.Sender
+	t Tracer
+}
+
+// Sender wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func Sender(v cchat.Sender, t Tracer) cchat.Sender {
+	if v == nil {
+		return nil
+	}
+	return sender{v, t}
+}
+
+func (w sender) Send(ctx context.Context, a0 cchat.SendableMessage) error {
+	c := beginBlocking(w.t, "cchat.Sender", "Send", a0)
+	err := w.v.Send(ctx, SendableMessage(a0, w.t))
+	c.end(err)
+	return err
+}
+
+func (w sender) CanAttach() bool {
+	c := begin(w.t, "cchat.Sender", "CanAttach")
+	r0 := w.v.CanAttach()
+	c.end(r0)
+	return r0
+}
+
+func (w sender) AsCompleter() cchat.Completer {
+	return Completer(w.v.AsCompleter(), w.t)
+}
+
+func (w sender) AsSendStateIndicator() cchat.SendStateIndicator {
+	return SendStateIndicator(w.v.AsSendStateIndicator(), w.t)
+}
+
+type sendStateIndicator struct {
+	v cchat.SendStateIndicator
+	t Tracer
+}
+
+// SendStateIndicator wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func SendStateIndicator(v cchat.SendStateIndicator, t Tracer) cchat.SendStateIndicator {
+	if v == nil {
+		return nil
+	}
+	return sendStateIndicator{v, t}
+}
+
+func (w sendStateIndicator) SendStateIndicate(ctx context.Context, a0 cchat.SendStateContainer) (func(), error) {
+	c := beginBlocking(w.t, "cchat.SendStateIndicator", "SendStateIndicate", a0)
+	stop, err := w.v.SendStateIndicate(ctx, SendStateContainer(a0, w.t))
+	c.end(err)
+	return stopFunc(w.t, "cchat.SendStateIndicator", "SendStateIndicate", stop), err
+}
+
+type editor struct {
+	v cchat.Editor
+	t Tracer
+}
+
+// Editor wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func Editor(v cchat.Editor, t Tracer) cchat.Editor {
+	if v == nil {
+		return nil
+	}
+	return editor{v, t}
+}
+
+func (w editor) IsEditable(a0 cchat.ID) bool {
+	c := begin(w.t, "cchat.Editor", "IsEditable", a0)
+	r0 := w.v.IsEditable(a0)
+	c.end(r0)
+	return r0
+}
+
+func (w editor) RawContent(a0 cchat.ID) (string, error) {
+	c := begin(w.t, "cchat.Editor", "RawContent", a0)
+	r0, err := w.v.RawContent(a0)
+	c.end(r0, err)
+	return r0, err
+}
+
+func (w editor) Edit(ctx context.Context, a0 cchat.ID, a1 string) error {
+	c := beginBlocking(w.t, "cchat.Editor", "Edit", a0, a1)
+	err := w.v.Edit(ctx, a0, a1)
+	c.end(err)
+	return err
+}
+
+type deleter struct {
+	v cchat.Deleter
+	t Tracer
+}
+
+// Deleter wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func Deleter(v cchat.Deleter, t Tracer) cchat.Deleter {
+	if v == nil {
+		return nil
+	}
+	return deleter{v, t}
+}
+
+func (w deleter) IsDeletable(a0 cchat.ID) bool {
+	c := begin(w.t, "cchat.Deleter", "IsDeletable", a0)
+	r0 := w.v.IsDeletable(a0)
+	c.end(r0)
+	return r0
+}
+
+func (w deleter) Delete(ctx context.Context, a0 cchat.ID) error {
+	c := beginBlocking(w.t, "cchat.Deleter", "Delete", a0)
+	err := w.v.Delete(ctx, a0)
+	c.end(err)
+	return err
+}
+
+type pinner struct {
+	v cchat.Pinner
+	t Tracer
+}
+
+// Pinner wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func Pinner(v cchat.Pinner, t Tracer) cchat.Pinner {
+	if v == nil {
+		return nil
+	}
+	return pinner{v, t}
+}
+
+func (w pinner) IsPinnable(a0 cchat.ID) bool {
+	c := begin(w.t, "cchat.Pinner", "IsPinnable", a0)
+	r0 := w.v.IsPinnable(a0)
+	c.end(r0)
+	return r0
+}
+
+func (w pinner) Pin(ctx context.Context, a0 cchat.ID) error {
+	c := beginBlocking(w.t, "cchat.Pinner", "Pin", a0)
+	err := w.v.Pin(ctx, a0)
+	c.end(err)
+	return err
+}
+
+func (w pinner) Unpin(ctx context.Context, a0 cchat.ID) error {
+	c := beginBlocking(w.t, "cchat.Pinner", "Unpin", a0)
+	err := w.v.Unpin(ctx, a0)
+	c.end(err)
+	return err
+}
+
+func (w pinner) Pins(ctx context.Context, a0 cchat.MessagesContainer) (func(), error) {
+	c := beginBlocking(w.t, "cchat.Pinner", "Pins", a0)
+	stop, err := w.v.Pins(ctx, MessagesContainer(a0, w.t))
+	c.end(err)
+	return stopFunc(w.t, "cchat.Pinner", "Pins", stop), err
+}
+
+type actioner struct {
+	v cchat.Actioner
+	t Tracer
+}
+
+// Actioner wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func Actioner(v cchat.Actioner, t Tracer) cchat.Actioner {
+	if v == nil {
+		return nil
+	}
+	return actioner{v, t}
+}
+
+func (w actioner) Actions(a0 cchat.ID) []string {
+	c := begin(w.t, "cchat.Actioner", "Actions", a0)
+	r0 := w.v.Actions(a0)
+	c.end(r0)
+	return r0
+}
+
+func (w actioner) Do(ctx context.Context, a0 string, a1 cchat.ID) error {
+	c := beginBlocking(w.t, "cchat.Actioner", "Do", a0, a1)
+	err := w.v.Do(ctx, a0, a1)
+	c.end(err)
+	return err
+}
+
+func (w actioner) AsActionDescriber() cchat.ActionDescriber {
+	return ActionDescriber(w.v.AsActionDescriber(), w.t)
+}
+
+type actionDescriber struct {
+	v cchat.ActionDescriber
+	t Tracer
+}
+
+// ActionDescriber wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func ActionDescriber(v cchat.ActionDescriber, t Tracer) cchat.ActionDescriber {
+	if v == nil {
+		return nil
+	}
+	return actionDescriber{v, t}
+}
+
+func (w actionDescriber) DescribeActions(a0 cchat.ID) []cchat.ActionDescriptor {
+	c := begin(w.t, "cchat.ActionDescriber", "DescribeActions", a0)
+	r0 := w.v.DescribeActions(a0)
+	c.end(r0)
+	return r0
+}
+
+func (w actionDescriber) DoInput(ctx context.Context, a0 string, a1 cchat.ID, a2 []string) error {
+	c := beginBlocking(w.t, "cchat.ActionDescriber", "DoInput", a0, a1, a2)
+	err := w.v.DoInput(ctx, a0, a1, a2)
+	c.end(err)
+	return err
+}
+
+type nicknamer struct {
+	v cchat.Nicknamer
+	t Tracer
+}
+
+// Nicknamer wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func Nicknamer(v cchat.Nicknamer, t Tracer) cchat.Nicknamer {
+	if v == nil {
+		return nil
+	}
+	return nicknamer{v, t}
+}
+
+func (w nicknamer) Name(ctx context.Context, a0 cchat.LabelContainer) (func(), error) {
+	c := beginBlocking(w.t, "cchat.Nicknamer", "Name", a0)
+	stop, err := w.v.Name(ctx, LabelContainer(a0, w.t))
+	c.end(err)
+	return stopFunc(w.t, "cchat.Nicknamer", "Name", stop), err
+}
+
+type backlogger struct {
+	v cchat.Backlogger
+	t Tracer
+}
+
+// Backlogger wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func Backlogger(v cchat.Backlogger, t Tracer) cchat.Backlogger {
+	if v == nil {
+		return nil
+	}
+	return backlogger{v, t}
+}
+
+func (w backlogger) Backlog(ctx context.Context, a0 cchat.ID, a1 cchat.MessagesContainer) error {
+	c := beginBlocking(w.t, "cchat.Backlogger", "Backlog", a0, a1)
+	err := w.v.Backlog(ctx, a0, MessagesContainer(a1, w.t))
+	c.end(err)
+	return err
+}
+
+type memberLister struct {
+	v cchat.MemberLister
+	t Tracer
+}
+
+// MemberLister wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func MemberLister(v cchat.MemberLister, t Tracer) cchat.MemberLister {
+	if v == nil {
+		return nil
+	}
+	return memberLister{v, t}
+}
+
+func (w memberLister) ListMembers(ctx context.Context, a0 cchat.MemberListContainer) (func(), error) {
+	c := beginBlocking(w.t, "cchat.MemberLister", "ListMembers", a0)
+	stop, err := w.v.ListMembers(ctx, MemberListContainer(a0, w.t))
+	c.end(err)
+	return stopFunc(w.t, "cchat.MemberLister", "ListMembers", stop), err
+}
+
+type readIndicator struct {
+	v cchat.ReadIndicator
+	t Tracer
+}
+
+// ReadIndicator wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func ReadIndicator(v cchat.ReadIndicator, t Tracer) cchat.ReadIndicator {
+	if v == nil {
+		return nil
+	}
+	return readIndicator{v, t}
+}
+
+func (w readIndicator) ReadIndicate(ctx context.Context, a0 cchat.ReadContainer) (func(), error) {
+	c := beginBlocking(w.t, "cchat.ReadIndicator", "ReadIndicate", a0)
+	stop, err := w.v.ReadIndicate(ctx, ReadContainer(a0, w.t))
+	c.end(err)
+	return stopFunc(w.t, "cchat.ReadIndicator", "ReadIndicate", stop), err
+}
+
+type unreadIndicator struct {
+	v cchat.UnreadIndicator
+	t Tracer
+}
+
+// UnreadIndicator wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func UnreadIndicator(v cchat.UnreadIndicator, t Tracer) cchat.UnreadIndicator {
+	if v == nil {
+		return nil
+	}
+	return unreadIndicator{v, t}
+}
+
+func (w unreadIndicator) MarkRead(ctx context.Context, a0 cchat.ID) {
+	c := begin(w.t, "cchat.UnreadIndicator", "MarkRead", a0)
+	w.v.MarkRead(ctx, a0)
+	c.end()
+}
+
+func (w unreadIndicator) UnreadIndicate(ctx context.Context, a0 cchat.UnreadContainer) (func(), error) {
+	c := beginBlocking(w.t, "cchat.UnreadIndicator", "UnreadIndicate", a0)
+	stop, err := w.v.UnreadIndicate(ctx, UnreadContainer(a0, w.t))
+	c.end(err)
+	return stopFunc(w.t, "cchat.UnreadIndicator", "UnreadIndicate", stop), err
+}
+
+type typingIndicator struct {
+	v cchat.TypingIndicator
+	t Tracer
+}
+
+// TypingIndicator wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func TypingIndicator(v cchat.TypingIndicator, t Tracer) cchat.TypingIndicator {
+	if v == nil {
+		return nil
+	}
+	return typingIndicator{v, t}
+}
+
+func (w typingIndicator) Typing(ctx context.Context) error {
+	c := beginBlocking(w.t, "cchat.TypingIndicator", "Typing")
+	err := w.v.Typing(ctx)
+	c.end(err)
+	return err
+}
+
+func (w typingIndicator) TypingTimeout() time.Duration {
+	c := begin(w.t, "cchat.TypingIndicator", "TypingTimeout")
+	r0 := w.v.TypingTimeout()
+	c.end(r0)
+	return r0
+}
+
+func (w typingIndicator) TypingSubscribe(ctx context.Context, a0 cchat.TypingContainer) (func(), error) {
+	c := beginBlocking(w.t, "cchat.TypingIndicator", "TypingSubscribe", a0)
+	stop, err := w.v.TypingSubscribe(ctx, TypingContainer(a0, w.t))
+	c.end(err)
+	return stopFunc(w.t, "cchat.TypingIndicator", "TypingSubscribe", stop), err
+}
+
+type profiler struct {
+	v cchat.Profiler
+	t Tracer
+}
+
+// Profiler wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func Profiler(v cchat.Profiler, t Tracer) cchat.Profiler {
+	if v == nil {
+		return nil
+	}
+	return profiler{v, t}
+}
+
+func (w profiler) Profile(ctx context.Context, a0 cchat.ID, a1 cchat.ProfileContainer) error {
+	c := beginBlocking(w.t, "cchat.Profiler", "Profile", a0, a1)
+	err := w.v.Profile(ctx, a0, ProfileContainer(a1, w.t))
+	c.end(err)
+	return err
+}
+
+type completer struct {
+	v cchat.Completer
+	t Tracer
+}
+
+// Completer wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func Completer(v cchat.Completer, t Tracer) cchat.Completer {
+	if v == nil {
+		return nil
+	}
+	return completer{v, t}
+}
+
+func (w completer) Complete(a0 []string, a1 int64) []cchat.CompletionEntry {
+	c := begin(w.t, "cchat.Completer", "Complete", a0, a1)
+	r0 := w.v.Complete(a0, a1)
+	c.end(r0)
+	return r0
+}
+
+type serversContainer struct {
+	v cchat.ServersContainer
+	t Tracer
+}
+
+// ServersContainer wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func ServersContainer(v cchat.ServersContainer, t Tracer) cchat.ServersContainer {
+	if v == nil {
+		return nil
+	}
+	return serversContainer{v, t}
+}
+
+func (w serversContainer) SetServers(ctx context.Context, a0 []cchat.Server) {
+	c := begin(w.t, "cchat.ServersContainer", "SetServers", a0)
+	w.v.SetServers(ctx, serverSlice(a0, w.t))
+	c.end()
+}
+
+func (w serversContainer) UpdateServer(ctx context.Context, a0 cchat.ServerUpdate) {
+	c := begin(w.t, "cchat.ServersContainer", "UpdateServer", a0)
+	w.v.UpdateServer(ctx, ServerUpdate(a0, w.t))
+	c.end()
+}
+
+type serverUpdate struct {
+	v cchat.ServerUpdate
+	t Tracer
+}
+
+// ServerUpdate wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func ServerUpdate(v cchat.ServerUpdate, t Tracer) cchat.ServerUpdate {
+	if v == nil {
+		return nil
+	}
+	return serverUpdate{v, t}
+}
+
+func (w serverUpdate) ID() cchat.ID {
+	c := begin(w.t, "cchat.ServerUpdate", "ID")
+	r0 := w.v.ID()
+	c.end(r0)
+	return r0
+}
+
+func (w serverUpdate) Name(ctx context.Context, a0 cchat.LabelContainer) (func(), error) {
+	c := beginBlocking(w.t, "cchat.ServerUpdate", "Name", a0)
+	stop, err := w.v.Name(ctx, LabelContainer(a0, w.t))
+	c.end(err)
+	return stopFunc(w.t, "cchat.ServerUpdate", "Name", stop), err
+}
+
+func (w serverUpdate) AsLister() cchat.Lister {
+	return Lister(w.v.AsLister(), w.t)
+}
+
+func (w serverUpdate) AsMessenger() cchat.Messenger {
+	return Messenger(w.v.AsMessenger(), w.t)
+}
+
+func (w serverUpdate) AsCommander() cchat.Commander {
+	return Commander(w.v.AsCommander(), w.t)
+}
+
+func (w serverUpdate) AsConfigurator() cchat.Configurator {
+	return Configurator(w.v.AsConfigurator(), w.t)
+}
+
+func (w serverUpdate) AsNotificationSettings() cchat.NotificationSettings {
+	return NotificationSettings(w.v.AsNotificationSettings(), w.t)
+}
+
+func (w serverUpdate) PreviousID() (cchat.ID, bool) {
+	c := begin(w.t, "cchat.ServerUpdate", "PreviousID")
+	r0, r1 := w.v.PreviousID()
+	c.end(r0, r1)
+	return r0, r1
+}
+
+type messagesContainer struct {
+	v cchat.MessagesContainer
+	t Tracer
+}
+
+// MessagesContainer wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func MessagesContainer(v cchat.MessagesContainer, t Tracer) cchat.MessagesContainer {
+	if v == nil {
+		return nil
+	}
+	return messagesContainer{v, t}
+}
+
+func (w messagesContainer) CreateMessage(ctx context.Context, a0 cchat.MessageCreate) {
+	c := begin(w.t, "cchat.MessagesContainer", "CreateMessage", a0)
+	w.v.CreateMessage(ctx, MessageCreate(a0, w.t))
+	c.end()
+}
+
+func (w messagesContainer) UpdateMessage(ctx context.Context, a0 cchat.MessageUpdate) {
+	c := begin(w.t, "cchat.MessagesContainer", "UpdateMessage", a0)
+	w.v.UpdateMessage(ctx, MessageUpdate(a0, w.t))
+	c.end()
+}
+
+func (w messagesContainer) DeleteMessage(ctx context.Context, a0 cchat.MessageDelete) {
+	c := begin(w.t, "cchat.MessagesContainer", "DeleteMessage", a0)
+	w.v.DeleteMessage(ctx, MessageDelete(a0, w.t))
+	c.end()
+}
+
+type messageHeader struct {
+	v cchat.MessageHeader
+	t Tracer
+}
+
+// MessageHeader wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func MessageHeader(v cchat.MessageHeader, t Tracer) cchat.MessageHeader {
+	if v == nil {
+		return nil
+	}
+	return messageHeader{v, t}
+}
+
+func (w messageHeader) ID() cchat.ID {
+	c := begin(w.t, "cchat.MessageHeader", "ID")
+	r0 := w.v.ID()
+	c.end(r0)
+	return r0
+}
+
+func (w messageHeader) Time() time.Time {
+	c := begin(w.t, "cchat.MessageHeader", "Time")
+	r0 := w.v.Time()
+	c.end(r0)
+	return r0
+}
+
+type messageCreate struct {
+	v cchat.MessageCreate
+	t Tracer
+}
+
+// MessageCreate wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func MessageCreate(v cchat.MessageCreate, t Tracer) cchat.MessageCreate {
+	if v == nil {
+		return nil
+	}
+	return messageCreate{v, t}
+}
+
+func (w messageCreate) ID() cchat.ID {
+	c := begin(w.t, "cchat.MessageCreate", "ID")
+	r0 := w.v.ID()
+	c.end(r0)
+	return r0
+}
+
+func (w messageCreate) Time() time.Time {
+	c := begin(w.t, "cchat.MessageCreate", "Time")
+	r0 := w.v.Time()
+	c.end(r0)
+	return r0
+}
+
+func (w messageCreate) Nonce() string {
+	c := begin(w.t, "cchat.MessageCreate", "Nonce")
+	r0 := w.v.Nonce()
+	c.end(r0)
+	return r0
+}
+
+func (w messageCreate) Author() cchat.User {
+	c := begin(w.t, "cchat.MessageCreate", "Author")
+	r0 := w.v.Author()
+	c.end(r0)
+	return User(r0, w.t)
+}
+
+func (w messageCreate) Content() text.Rich {
+	c := begin(w.t, "cchat.MessageCreate", "Content")
+	r0 := w.v.Content()
+	c.end(r0)
+	return r0
+}
+
+func (w messageCreate) Mentioned() bool {
+	c := begin(w.t, "cchat.MessageCreate", "Mentioned")
+	r0 := w.v.Mentioned()
+	c.end(r0)
+	return r0
+}
+
+func (w messageCreate) AsMassMentioner() cchat.MassMentioner {
+	return MassMentioner(w.v.AsMassMentioner(), w.t)
+}
+
+type massMentioner struct {
+	v cchat.MassMentioner
+	t Tracer
+}
+
+// MassMentioner wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func MassMentioner(v cchat.MassMentioner, t Tracer) cchat.MassMentioner {
+	if v == nil {
+		return nil
+	}
+	return massMentioner{v, t}
+}
+
+func (w massMentioner) MassMentioned() bool {
+	c := begin(w.t, "cchat.MassMentioner", "MassMentioned")
+	r0 := w.v.MassMentioned()
+	c.end(r0)
+	return r0
+}
+
+type messageUpdate struct {
+	v cchat.MessageUpdate
+	t Tracer
+}
+
+// MessageUpdate wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func MessageUpdate(v cchat.MessageUpdate, t Tracer) cchat.MessageUpdate {
+	if v == nil {
+		return nil
+	}
+	return messageUpdate{v, t}
+}
+
+func (w messageUpdate) ID() cchat.ID {
+	c := begin(w.t, "cchat.MessageUpdate", "ID")
+	r0 := w.v.ID()
+	c.end(r0)
+	return r0
+}
+
+func (w messageUpdate) Time() time.Time {
+	c := begin(w.t, "cchat.MessageUpdate", "Time")
+	r0 := w.v.Time()
+	c.end(r0)
+	return r0
+}
+
+func (w messageUpdate) Content() text.Rich {
+	c := begin(w.t, "cchat.MessageUpdate", "Content")
+	r0 := w.v.Content()
+	c.end(r0)
+	return r0
+}
+
+type messageDelete struct {
+	v cchat.MessageDelete
+	t Tracer
+}
+
+// MessageDelete wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func MessageDelete(v cchat.MessageDelete, t Tracer) cchat.MessageDelete {
+	if v == nil {
+		return nil
+	}
+	return messageDelete{v, t}
+}
+
+func (w messageDelete) ID() cchat.ID {
+	c := begin(w.t, "cchat.MessageDelete", "ID")
+	r0 := w.v.ID()
+	c.end(r0)
+	return r0
+}
+
+func (w messageDelete) Time() time.Time {
+	c := begin(w.t, "cchat.MessageDelete", "Time")
+	r0 := w.v.Time()
+	c.end(r0)
+	return r0
+}
+
+type labelContainer struct {
+	v cchat.LabelContainer
+	t Tracer
+}
+
+// LabelContainer wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func LabelContainer(v cchat.LabelContainer, t Tracer) cchat.LabelContainer {
+	if v == nil {
+		return nil
+	}
+	return labelContainer{v, t}
+}
+
+func (w labelContainer) SetLabel(ctx context.Context, a0 text.Rich) {
+	c := begin(w.t, "cchat.LabelContainer", "SetLabel", a0)
+	w.v.SetLabel(ctx, a0)
+	c.end()
+}
+
+type profileContainer struct {
+	v cchat.ProfileContainer
+	t Tracer
+}
+
+// ProfileContainer wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func ProfileContainer(v cchat.ProfileContainer, t Tracer) cchat.ProfileContainer {
+	if v == nil {
+		return nil
+	}
+	return profileContainer{v, t}
+}
+
+func (w profileContainer) SetAvatar(ctx context.Context, a0 string) {
+	c := begin(w.t, "cchat.ProfileContainer", "SetAvatar", a0)
+	w.v.SetAvatar(ctx, a0)
+	c.end()
+}
+
+func (w profileContainer) SetDisplayName(ctx context.Context, a0 text.Rich) {
+	c := begin(w.t, "cchat.ProfileContainer", "SetDisplayName", a0)
+	w.v.SetDisplayName(ctx, a0)
+	c.end()
+}
+
+func (w profileContainer) SetBio(ctx context.Context, a0 text.Rich) {
+	c := begin(w.t, "cchat.ProfileContainer", "SetBio", a0)
+	w.v.SetBio(ctx, a0)
+	c.end()
+}
+
+func (w profileContainer) SetStatus(ctx context.Context, a0 cchat.Status, a1 text.Rich) {
+	c := begin(w.t, "cchat.ProfileContainer", "SetStatus", a0, a1)
+	w.v.SetStatus(ctx, a0, a1)
+	c.end()
+}
+
+func (w profileContainer) SetRoles(ctx context.Context, a0 []cchat.Role) {
+	c := begin(w.t, "cchat.ProfileContainer", "SetRoles", a0)
+	w.v.SetRoles(ctx, a0)
+	c.end()
+}
+
+func (w profileContainer) SetMutualServers(ctx context.Context, a0 []cchat.Server) {
+	c := begin(w.t, "cchat.ProfileContainer", "SetMutualServers", a0)
+	w.v.SetMutualServers(ctx, serverSlice(a0, w.t))
+	c.end()
+}
+
+type readContainer struct {
+	v cchat.ReadContainer
+	t Tracer
+}
+
+// ReadContainer wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func ReadContainer(v cchat.ReadContainer, t Tracer) cchat.ReadContainer {
+	if v == nil {
+		return nil
+	}
+	return readContainer{v, t}
+}
+
+func (w readContainer) AddIndications(ctx context.Context, a0 []cchat.ReadIndication) {
+	c := begin(w.t, "cchat.ReadContainer", "AddIndications", a0)
+	w.v.AddIndications(ctx, a0)
+	c.end()
+}
+
+func (w readContainer) DeleteIndications(ctx context.Context, a0 []cchat.ID) {
+	c := begin(w.t, "cchat.ReadContainer", "DeleteIndications", a0)
+	w.v.DeleteIndications(ctx, a0)
+	c.end()
+}
+
+type unreadContainer struct {
+	v cchat.UnreadContainer
+	t Tracer
+}
+
+// UnreadContainer wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func UnreadContainer(v cchat.UnreadContainer, t Tracer) cchat.UnreadContainer {
+	if v == nil {
+		return nil
+	}
+	return unreadContainer{v, t}
+}
+
+func (w unreadContainer) SetUnread(ctx context.Context, a0 bool, a1 bool) {
+	c := begin(w.t, "cchat.UnreadContainer", "SetUnread", a0, a1)
+	w.v.SetUnread(ctx, a0, a1)
+	c.end()
+}
+
+func (w unreadContainer) AsUnreadCountContainer() cchat.UnreadCountContainer {
+	return UnreadCountContainer(w.v.AsUnreadCountContainer(), w.t)
+}
+
+type unreadCountContainer struct {
+	v cchat.UnreadCountContainer
+	t Tracer
+}
+
+// UnreadCountContainer wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func UnreadCountContainer(v cchat.UnreadCountContainer, t Tracer) cchat.UnreadCountContainer {
+	if v == nil {
+		return nil
+	}
+	return unreadCountContainer{v, t}
+}
+
+func (w unreadCountContainer) SetUnreadCount(ctx context.Context, a0 int, a1 int) {
+	c := begin(w.t, "cchat.UnreadCountContainer", "SetUnreadCount", a0, a1)
+	w.v.SetUnreadCount(ctx, a0, a1)
+	c.end()
+}
+
+type connectionStateContainer struct {
+	v cchat.ConnectionStateContainer
+	t Tracer
+}
+
+// ConnectionStateContainer wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func ConnectionStateContainer(v cchat.ConnectionStateContainer, t Tracer) cchat.ConnectionStateContainer {
+	if v == nil {
+		return nil
+	}
+	return connectionStateContainer{v, t}
+}
+
+func (w connectionStateContainer) SetConnectionState(ctx context.Context, a0 cchat.ConnectionState, a1 error) {
+	c := begin(w.t, "cchat.ConnectionStateContainer", "SetConnectionState", a0, a1)
+	w.v.SetConnectionState(ctx, a0, a1)
+	c.end()
+}
+
+func (w connectionStateContainer) SetLatency(ctx context.Context, a0 time.Duration) {
+	c := begin(w.t, "cchat.ConnectionStateContainer", "SetLatency", a0)
+	w.v.SetLatency(ctx, a0)
+	c.end()
+}
+
+type presenceContainer struct {
+	v cchat.PresenceContainer
+	t Tracer
+}
+
+// PresenceContainer wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func PresenceContainer(v cchat.PresenceContainer, t Tracer) cchat.PresenceContainer {
+	if v == nil {
+		return nil
+	}
+	return presenceContainer{v, t}
+}
+
+func (w presenceContainer) SetPresence(ctx context.Context, a0 cchat.Presence) {
+	c := begin(w.t, "cchat.PresenceContainer", "SetPresence", a0)
+	w.v.SetPresence(ctx, a0)
+	c.end()
+}
+
+type sendStateContainer struct {
+	v cchat.SendStateContainer
+	t Tracer
+}
+
+// SendStateContainer wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func SendStateContainer(v cchat.SendStateContainer, t Tracer) cchat.SendStateContainer {
+	if v == nil {
+		return nil
+	}
+	return sendStateContainer{v, t}
+}
+
+func (w sendStateContainer) SetSendState(ctx context.Context, a0 string, a1 cchat.SendState, a2 error) {
+	c := begin(w.t, "cchat.SendStateContainer", "SetSendState", a0, a1, a2)
+	w.v.SetSendState(ctx, a0, a1, a2)
+	c.end()
+}
+
+type typingContainer struct {
+	v cchat.TypingContainer
+	t Tracer
+}
+
+// TypingContainer wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func TypingContainer(v cchat.TypingContainer, t Tracer) cchat.TypingContainer {
+	if v == nil {
+		return nil
+	}
+	return typingContainer{v, t}
+}
+
+func (w typingContainer) AddTyper(ctx context.Context, a0 cchat.User) {
+	c := begin(w.t, "cchat.TypingContainer", "AddTyper", a0)
+	w.v.AddTyper(ctx, User(a0, w.t))
+	c.end()
+}
+
+func (w typingContainer) RemoveTyper(ctx context.Context, a0 cchat.ID) {
+	c := begin(w.t, "cchat.TypingContainer", "RemoveTyper", a0)
+	w.v.RemoveTyper(ctx, a0)
+	c.end()
+}
+
+type memberListContainer struct {
+	v cchat.MemberListContainer
+	t Tracer
+}
+
+// MemberListContainer wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func MemberListContainer(v cchat.MemberListContainer, t Tracer) cchat.MemberListContainer {
+	if v == nil {
+		return nil
+	}
+	return memberListContainer{v, t}
+}
+
+func (w memberListContainer) SetSections(ctx context.Context, a0 []cchat.MemberSection) {
+	c := begin(w.t, "cchat.MemberListContainer", "SetSections", a0)
+	w.v.SetSections(ctx, memberSectionSlice(a0, w.t))
+	c.end()
+}
+
+func (w memberListContainer) SetMember(ctx context.Context, a0 cchat.ID, a1 cchat.ListMember) {
+	c := begin(w.t, "cchat.MemberListContainer", "SetMember", a0, a1)
+	w.v.SetMember(ctx, a0, ListMember(a1, w.t))
+	c.end()
+}
+
+func (w memberListContainer) RemoveMember(ctx context.Context, a0 cchat.ID, a1 cchat.ID) {
+	c := begin(w.t, "cchat.MemberListContainer", "RemoveMember", a0, a1)
+	w.v.RemoveMember(ctx, a0, a1)
+	c.end()
+}
+
+type listMember struct {
+	v cchat.ListMember
+	t Tracer
+}
+
+// ListMember wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func ListMember(v cchat.ListMember, t Tracer) cchat.ListMember {
+	if v == nil {
+		return nil
+	}
+	return listMember{v, t}
+}
+
+func (w listMember) ID() cchat.ID {
+	c := begin(w.t, "cchat.ListMember", "ID")
+	r0 := w.v.ID()
+	c.end(r0)
+	return r0
+}
+
+func (w listMember) Name() text.Rich {
+	c := begin(w.t, "cchat.ListMember", "Name")
+	r0 := w.v.Name()
+	c.end(r0)
+	return r0
+}
+
+func (w listMember) Status() cchat.Status {
+	c := begin(w.t, "cchat.ListMember", "Status")
+	r0 := w.v.Status()
+	c.end(r0)
+	return r0
+}
+
+func (w listMember) Secondary() text.Rich {
+	c := begin(w.t, "cchat.ListMember", "Secondary")
+	r0 := w.v.Secondary()
+	c.end(r0)
+	return r0
+}
+
+func (w listMember) AsDirectMessager() cchat.DirectMessager {
+	return DirectMessager(w.v.AsDirectMessager(), w.t)
+}
+
+type memberSection struct {
+	v cchat.MemberSection
+	t Tracer
+}
+
+// MemberSection wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func MemberSection(v cchat.MemberSection, t Tracer) cchat.MemberSection {
+	if v == nil {
+		return nil
+	}
+	return memberSection{v, t}
+}
+
+func (w memberSection) ID() cchat.ID {
+	c := begin(w.t, "cchat.MemberSection", "ID")
+	r0 := w.v.ID()
+	c.end(r0)
+	return r0
+}
+
+func (w memberSection) Name(ctx context.Context, a0 cchat.LabelContainer) (func(), error) {
+	c := beginBlocking(w.t, "cchat.MemberSection", "Name", a0)
+	stop, err := w.v.Name(ctx, LabelContainer(a0, w.t))
+	c.end(err)
+	return stopFunc(w.t, "cchat.MemberSection", "Name", stop), err
+}
+
+func (w memberSection) Total() int {
+	c := begin(w.t, "cchat.MemberSection", "Total")
+	r0 := w.v.Total()
+	c.end(r0)
+	return r0
+}
+
+func (w memberSection) AsMemberDynamicSection() cchat.MemberDynamicSection {
+	return MemberDynamicSection(w.v.AsMemberDynamicSection(), w.t)
+}
+
+type memberDynamicSection struct {
+	v cchat.MemberDynamicSection
+	t Tracer
+}
+
+// MemberDynamicSection wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func MemberDynamicSection(v cchat.MemberDynamicSection, t Tracer) cchat.MemberDynamicSection {
+	if v == nil {
+		return nil
+	}
+	return memberDynamicSection{v, t}
+}
+
+func (w memberDynamicSection) LoadMore(ctx context.Context) bool {
+	c := beginBlocking(w.t, "cchat.MemberDynamicSection", "LoadMore")
+	r0 := w.v.LoadMore(ctx)
+	c.end(r0)
+	return r0
+}
+
+func (w memberDynamicSection) LoadLess(ctx context.Context) bool {
+	c := beginBlocking(w.t, "cchat.MemberDynamicSection", "LoadLess")
+	r0 := w.v.LoadLess(ctx)
+	c.end(r0)
+	return r0
+}
+
+type sendableMessage struct {
+	v cchat.SendableMessage
+	t Tracer
+}
+
+// SendableMessage wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func SendableMessage(v cchat.SendableMessage, t Tracer) cchat.SendableMessage {
+	if v == nil {
+		return nil
+	}
+	return sendableMessage{v, t}
+}
+
+func (w sendableMessage) Content() string {
+	c := begin(w.t, "cchat.SendableMessage", "Content")
+	r0 := w.v.Content()
+	c.end(r0)
+	return r0
+}
+
+func (w sendableMessage) AsNoncer() cchat.Noncer {
+	return Noncer(w.v.AsNoncer(), w.t)
+}
+
+func (w sendableMessage) AsReplier() cchat.Replier {
+	return Replier(w.v.AsReplier(), w.t)
+}
+
+func (w sendableMessage) AsAttacher() cchat.Attacher {
+	return Attacher(w.v.AsAttacher(), w.t)
+}
+
+type replier struct {
+	v cchat.Replier
+	t Tracer
+}
+
+// Replier wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func Replier(v cchat.Replier, t Tracer) cchat.Replier {
+	if v == nil {
+		return nil
+	}
+	return replier{v, t}
+}
+
+func (w replier) ReplyingTo() cchat.ID {
+	c := begin(w.t, "cchat.Replier", "ReplyingTo")
+	r0 := w.v.ReplyingTo()
+	c.end(r0)
+	return r0
+}
+
+type attacher struct {
+	v cchat.Attacher
+	t Tracer
+}
+
+// Attacher wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func Attacher(v cchat.Attacher, t Tracer) cchat.Attacher {
+	if v == nil {
+		return nil
+	}
+	return attacher{v, t}
+}
+
+func (w attacher) Attachments() []cchat.MessageAttachment {
+	c := begin(w.t, "cchat.Attacher", "Attachments")
+	r0 := w.v.Attachments()
+	c.end(r0)
+	return r0
+}
+
+func (w attacher) AsUploadProgressContainer() cchat.UploadProgressContainer {
+	return UploadProgressContainer(w.v.AsUploadProgressContainer(), w.t)
+}
+
+type uploadProgressContainer struct {
+	v cchat.UploadProgressContainer
+	t Tracer
+}
+
+// UploadProgressContainer wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func UploadProgressContainer(v cchat.UploadProgressContainer, t Tracer) cchat.UploadProgressContainer {
+	if v == nil {
+		return nil
+	}
+	return uploadProgressContainer{v, t}
+}
+
+func (w uploadProgressContainer) SetUploadProgress(ctx context.Context, a0 int, a1 int64, a2 int64) {
+	c := begin(w.t, "cchat.UploadProgressContainer", "SetUploadProgress", a0, a1, a2)
+	w.v.SetUploadProgress(ctx, a0, a1, a2)
+	c.end()
+}
+
+type textSegment struct {
+	v text.Segment
+	t Tracer
+}
+
+// TextSegment wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func TextSegment(v text.Segment, t Tracer) text.Segment {
+	if v == nil {
+		return nil
+	}
+	return textSegment{v, t}
+}
+
+func (w textSegment) Bounds() (int, int) {
+	c := begin(w.t, "text.Segment", "Bounds")
+	r0, r1 := w.v.Bounds()
+	c.end(r0, r1)
+	return r0, r1
+}
+
+func (w textSegment) AsColorer() text.Colorer {
+	return TextColorer(w.v.AsColorer(), w.t)
+}
+
+func (w textSegment) AsLinker() text.Linker {
+	return TextLinker(w.v.AsLinker(), w.t)
+}
+
+func (w textSegment) AsImager() text.Imager {
+	return TextImager(w.v.AsImager(), w.t)
+}
+
+func (w textSegment) AsAvatarer() text.Avatarer {
+	return TextAvatarer(w.v.AsAvatarer(), w.t)
+}
+
+func (w textSegment) AsMentioner() text.Mentioner {
+	return TextMentioner(w.v.AsMentioner(), w.t)
+}
+
+func (w textSegment) AsAttributor() text.Attributor {
+	return TextAttributor(w.v.AsAttributor(), w.t)
+}
+
+func (w textSegment) AsCodeblocker() text.Codeblocker {
+	return TextCodeblocker(w.v.AsCodeblocker(), w.t)
+}
+
+func (w textSegment) AsQuoteblocker() text.Quoteblocker {
+	return TextQuoteblocker(w.v.AsQuoteblocker(), w.t)
+}
+
+func (w textSegment) AsMessageReferencer() text.MessageReferencer {
+	return TextMessageReferencer(w.v.AsMessageReferencer(), w.t)
+}
+
+type textMessageReferencer struct {
+	v text.MessageReferencer
+	t Tracer
+}
+
+// TextMessageReferencer wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func TextMessageReferencer(v text.MessageReferencer, t Tracer) text.MessageReferencer {
+	if v == nil {
+		return nil
+	}
+	return textMessageReferencer{v, t}
+}
+
+func (w textMessageReferencer) MessageID() string {
+	c := begin(w.t, "text.MessageReferencer", "MessageID")
+	r0 := w.v.MessageID()
+	c.end(r0)
+	return r0
+}
+
+type textLinker struct {
+	v text.Linker
+	t Tracer
+}
+
+// TextLinker wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func TextLinker(v text.Linker, t Tracer) text.Linker {
+	if v == nil {
+		return nil
+	}
+	return textLinker{v, t}
+}
+
+func (w textLinker) Link() string {
+	c := begin(w.t, "text.Linker", "Link")
+	r0 := w.v.Link()
+	c.end(r0)
+	return r0
+}
+
+type textImager struct {
+	v text.Imager
+	t Tracer
+}
+
+// TextImager wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func TextImager(v text.Imager, t Tracer) text.Imager {
+	if v == nil {
+		return nil
+	}
+	return textImager{v, t}
+}
+
+func (w textImager) Image() string {
+	c := begin(w.t, "text.Imager", "Image")
+	r0 := w.v.Image()
+	c.end(r0)
+	return r0
+}
+
+func (w textImager) ImageSize() (int, int) {
+	c := begin(w.t, "text.Imager", "ImageSize")
+	r0, r1 := w.v.ImageSize()
+	c.end(r0, r1)
+	return r0, r1
+}
+
+func (w textImager) ImageText() string {
+	c := begin(w.t, "text.Imager", "ImageText")
+	r0 := w.v.ImageText()
+	c.end(r0)
+	return r0
+}
+
+type textAvatarer struct {
+	v text.Avatarer
+	t Tracer
+}
+
+// TextAvatarer wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func TextAvatarer(v text.Avatarer, t Tracer) text.Avatarer {
+	if v == nil {
+		return nil
+	}
+	return textAvatarer{v, t}
+}
+
+func (w textAvatarer) Avatar() string {
+	c := begin(w.t, "text.Avatarer", "Avatar")
+	r0 := w.v.Avatar()
+	c.end(r0)
+	return r0
+}
+
+func (w textAvatarer) AvatarSize() int {
+	c := begin(w.t, "text.Avatarer", "AvatarSize")
+	r0 := w.v.AvatarSize()
+	c.end(r0)
+	return r0
+}
+
+func (w textAvatarer) AvatarText() string {
+	c := begin(w.t, "text.Avatarer", "AvatarText")
+	r0 := w.v.AvatarText()
+	c.end(r0)
+	return r0
+}
+
+type textColorer struct {
+	v text.Colorer
+	t Tracer
+}
+
+// TextColorer wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func TextColorer(v text.Colorer, t Tracer) text.Colorer {
+	if v == nil {
+		return nil
+	}
+	return textColorer{v, t}
+}
+
+func (w textColorer) Color() uint32 {
+	c := begin(w.t, "text.Colorer", "Color")
+	r0 := w.v.Color()
+	c.end(r0)
+	return r0
+}
+
+type textMentioner struct {
+	v text.Mentioner
+	t Tracer
+}
+
+// TextMentioner wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func TextMentioner(v text.Mentioner, t Tracer) text.Mentioner {
+	if v == nil {
+		return nil
+	}
+	return textMentioner{v, t}
+}
+
+func (w textMentioner) MentionInfo() text.Rich {
+	c := begin(w.t, "text.Mentioner", "MentionInfo")
+	r0 := w.v.MentionInfo()
+	c.end(r0)
+	return r0
+}
+
+type textAttributor struct {
+	v text.Attributor
+	t Tracer
+}
+
+// TextAttributor wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func TextAttributor(v text.Attributor, t Tracer) text.Attributor {
+	if v == nil {
+		return nil
+	}
+	return textAttributor{v, t}
+}
+
+func (w textAttributor) Attribute() text.Attribute {
+	c := begin(w.t, "text.Attributor", "Attribute")
+	r0 := w.v.Attribute()
+	c.end(r0)
+	return r0
+}
+
+type textCodeblocker struct {
+	v text.Codeblocker
+	t Tracer
+}
+
+// TextCodeblocker wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func TextCodeblocker(v text.Codeblocker, t Tracer) text.Codeblocker {
+	if v == nil {
+		return nil
+	}
+	return textCodeblocker{v, t}
+}
+
+func (w textCodeblocker) CodeblockLanguage() string {
+	c := begin(w.t, "text.Codeblocker", "CodeblockLanguage")
+	r0 := w.v.CodeblockLanguage()
+	c.end(r0)
+	return r0
+}
+
+type textQuoteblocker struct {
+	v text.Quoteblocker
+	t Tracer
+}
+
+// TextQuoteblocker wraps v to trace its method calls with t.
+// Nil is returned if v is nil.
+func TextQuoteblocker(v text.Quoteblocker, t Tracer) text.Quoteblocker {
+	if v == nil {
+		return nil
+	}
+	return textQuoteblocker{v, t}
+}
+
+func (w textQuoteblocker) QuotePrefix() string {
+	c := begin(w.t, "text.Quoteblocker", "QuotePrefix")
+	r0 := w.v.QuotePrefix()
+	c.end(r0)
+	return r0
+}
+
+func authenticatorSlice(v []cchat.Authenticator, t Tracer) []cchat.Authenticator {
+	if v == nil {
+		return nil
+	}
+	w := make([]cchat.Authenticator, len(v))
+	for i := range v {
+		w[i] = Authenticator(v[i], t)
+	}
+	return w
+}
+
+func serverSlice(v []cchat.Server, t Tracer) []cchat.Server {
+	if v == nil {
+		return nil
+	}
+	w := make([]cchat.Server, len(v))
+	for i := range v {
+		w[i] = Server(v[i], t)
+	}
+	return w
+}
+
+func memberSectionSlice(v []cchat.MemberSection, t Tracer) []cchat.MemberSection {
+	if v == nil {
+		return nil
+	}
+	w := make([]cchat.MemberSection, len(v))
+	for i := range v {
+		w[i] = MemberSection(v[i], t)
+	}
+	return w
+}
@@ -0,0 +1,91 @@
+package trace
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/diamondburned/cchat"
+	"github.com/diamondburned/cchat/text"
+	"github.com/diamondburned/cchat/utils/empty"
+	"github.com/go-test/deep"
+)
+
+type recorder struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (r *recorder) Trace(ev Event) {
+	ev.Duration = 0 // deterministic
+
+	r.mu.Lock()
+	r.events = append(r.events, ev.String())
+	r.mu.Unlock()
+}
+
+type testServer struct {
+	empty.Server
+}
+
+func (testServer) ID() cchat.ID { return "1" }
+
+func (testServer) Name(ctx context.Context, l cchat.LabelContainer) (func(), error) {
+	l.SetLabel(ctx, text.Plain("general"))
+	return func() {}, nil
+}
+
+func (testServer) AsMessenger() cchat.Messenger { return testMessenger{} }
+
+type testMessenger struct {
+	empty.Messenger
+}
+
+func (testMessenger) JoinServer(context.Context, cchat.MessagesContainer) (func(), error) {
+	return nil, errors.New("not joinable")
+}
+
+type testLabel struct{}
+
+func (testLabel) SetLabel(context.Context, text.Rich) {}
+
+func TestServer(t *testing.T) {
+	r := &recorder{}
+	s := Server(testServer{}, r)
+
+	s.ID()
+
+	stop, err := s.Name(context.Background(), testLabel{})
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	stop()
+
+	m := s.AsMessenger()
+	if _, err := m.JoinServer(context.Background(), nil); err == nil {
+		t.Fatal("Unexpected nil error")
+	}
+
+	if s.AsLister() != nil {
+		t.Fatal("Unexpected non-nil Lister")
+	}
+
+	var expect = []string{
+		"cchat.Server.ID() = 1",
+		"cchat.LabelContainer.SetLabel(general)",
+		"cchat.Server.Name({}) = <nil>",
+		"cchat.Server.Name stop()",
+		"cchat.Messenger.JoinServer(<nil>) = not joinable",
+	}
+
+	if eq := deep.Equal(expect, r.events); eq != nil {
+		t.Fatal("Unexpected events:", eq)
+	}
+}
+
+func TestNil(t *testing.T) {
+	if s := Server(nil, &recorder{}); s != nil {
+		t.Fatal("Unexpected non-nil Server")
+	}
+}
@@ -0,0 +1,330 @@
+// Package unread provides a helper for frontends to roll unread and mention
+// counts up the server tree, such as for guild or folder badges.
+//
+// Usage
+//
+// Each server in the tree is added with its parent's ID, and the container
+// returned by Add is given to the server's UnreadIndicate:
+//
+//    tree := unread.NewTree(func(id cchat.ID, total unread.Counts) {
+//        // Update the badge of the server or folder with the given ID.
+//    })
+//
+//    uc := tree.Add(parentID, server.ID())
+//    stop, err := indicator.UnreadIndicate(ctx, uc)
+//
+package unread
+
+import (
+	"context"
+	"sync"
+
+	"github.com/diamondburned/cchat"
+)
+
+// Counts is the number of unread messages and mentions.
+type Counts struct {
+	Unread   int
+	Mentions int
+}
+
+// IsZero returns true if there are no unread messages or mentions.
+func (c Counts) IsZero() bool {
+	return c.Unread == 0 && c.Mentions == 0
+}
+
+func (c Counts) add(other Counts) Counts {
+	return Counts{c.Unread + other.Unread, c.Mentions + other.Mentions}
+}
+
+func (c Counts) sub(other Counts) Counts {
+	return Counts{c.Unread - other.Unread, c.Mentions - other.Mentions}
+}
+
+// UpdateFunc is called with the ID of a server and its new total counts, which
+// include the counts of all its children.
+type UpdateFunc = func(id cchat.ID, total Counts)
+
+// Tree aggregates the counts of child servers up to their parents. All its
+// methods are thread-safe.
+type Tree struct {
+	update UpdateFunc
+
+	mutex sync.Mutex
+	nodes map[cchat.ID]*node
+}
+
+type node struct {
+	parent    cchat.ID
+	hasParent bool
+	children  map[cchat.ID]struct{}
+
+	self  Counts
+	total Counts
+}
+
+// NewTree creates a new tree. The given update function is called outside of
+// the lock every time the total counts of a server change.
+func NewTree(update UpdateFunc) *Tree {
+	if update == nil {
+		update = func(cchat.ID, Counts) {}
+	}
+
+	return &Tree{
+		update: update,
+		nodes:  map[cchat.ID]*node{},
+	}
+}
+
+// Add adds the server with the given ID as a child of the parent with the
+// given parent ID, creating the parent if it's not yet in the tree. If the
+// server is already in the tree, then it is moved along with its children.
+// The returned container updates the server's own counts.
+//
+// A server cannot be moved under itself or one of its descendants, since that
+// would make a cycle. It is left where it is in that case, or added without a
+// parent if it's new.
+func (t *Tree) Add(parentID, id cchat.ID) cchat.UnreadContainer {
+	t.mutex.Lock()
+
+	n := t.node(id)
+
+	if t.isAncestor(id, parentID) {
+		t.mutex.Unlock()
+		return container{t, id}
+	}
+
+	changes := t.detach(id, n)
+
+	parent := t.node(parentID)
+	parent.children[id] = struct{}{}
+	n.parent = parentID
+	n.hasParent = true
+
+	changes = changes.merge(t.propagate(parentID, n.total))
+
+	t.mutex.Unlock()
+
+	changes.call(t.update)
+
+	return container{t, id}
+}
+
+// AddRoot adds a server without a parent, such as a session node. It returns
+// the container for the server, similarly to Add.
+func (t *Tree) AddRoot(id cchat.ID) cchat.UnreadContainer {
+	t.mutex.Lock()
+	t.node(id)
+	t.mutex.Unlock()
+
+	return container{t, id}
+}
+
+// Remove removes the server with the given ID and all its children from the
+// tree, subtracting their counts from the parents.
+func (t *Tree) Remove(id cchat.ID) {
+	t.mutex.Lock()
+
+	n, ok := t.nodes[id]
+	if !ok {
+		t.mutex.Unlock()
+		return
+	}
+
+	changes := t.detach(id, n)
+	t.delete(id, n)
+
+	t.mutex.Unlock()
+
+	changes.call(t.update)
+}
+
+// Total returns the total counts of the server with the given ID, which
+// include the counts of its children.
+func (t *Tree) Total(id cchat.ID) Counts {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if n, ok := t.nodes[id]; ok {
+		return n.total
+	}
+	return Counts{}
+}
+
+func (t *Tree) set(id cchat.ID, set func(self Counts) Counts) {
+	t.mutex.Lock()
+
+	n, ok := t.nodes[id]
+	if !ok {
+		// The server was removed.
+		t.mutex.Unlock()
+		return
+	}
+
+	delta := set(n.self).sub(n.self)
+	n.self = n.self.add(delta)
+	changes := t.propagate(id, delta)
+
+	t.mutex.Unlock()
+
+	changes.call(t.update)
+}
+
+// node gets or creates the node with the given ID. The caller must hold the
+// lock.
+func (t *Tree) node(id cchat.ID) *node {
+	n, ok := t.nodes[id]
+	if !ok {
+		n = &node{children: map[cchat.ID]struct{}{}}
+		t.nodes[id] = n
+	}
+	return n
+}
+
+// isAncestor returns true if the node with the given ancestor ID is the node
+// with the given ID or one of its ancestors. The caller must hold the lock.
+func (t *Tree) isAncestor(ancestorID, id cchat.ID) bool {
+	for {
+		if id == ancestorID {
+			return true
+		}
+
+		n, ok := t.nodes[id]
+		if !ok || !n.hasParent {
+			return false
+		}
+		id = n.parent
+	}
+}
+
+// detach removes the node from its parent, subtracting its total from the
+// ancestors. The caller must hold the lock.
+func (t *Tree) detach(id cchat.ID, n *node) changes {
+	if !n.hasParent {
+		return nil
+	}
+
+	changes := t.propagate(n.parent, Counts{}.sub(n.total))
+
+	if parent, ok := t.nodes[n.parent]; ok {
+		delete(parent.children, id)
+	}
+	n.hasParent = false
+
+	return changes
+}
+
+// delete deletes the node and its children recursively. The caller must hold
+// the lock.
+func (t *Tree) delete(id cchat.ID, n *node) {
+	for childID := range n.children {
+		if child, ok := t.nodes[childID]; ok {
+			t.delete(childID, child)
+		}
+	}
+	delete(t.nodes, id)
+}
+
+// propagate adds delta to the node with the given ID and all its ancestors.
+// The caller must hold the lock.
+func (t *Tree) propagate(id cchat.ID, delta Counts) changes {
+	if delta.IsZero() {
+		return nil
+	}
+
+	var changes changes
+
+	for {
+		n, ok := t.nodes[id]
+		if !ok {
+			break
+		}
+
+		n.total = n.total.add(delta)
+		changes = append(changes, change{id, n.total})
+
+		if !n.hasParent {
+			break
+		}
+		id = n.parent
+	}
+
+	return changes
+}
+
+type change struct {
+	id    cchat.ID
+	total Counts
+}
+
+type changes []change
+
+func (c changes) merge(other changes) changes {
+	for _, ch := range other {
+		var found bool
+		for i := range c {
+			if c[i].id == ch.id {
+				c[i] = ch
+				found = true
+				break
+			}
+		}
+		if !found {
+			c = append(c, ch)
+		}
+	}
+	return c
+}
+
+func (c changes) call(update UpdateFunc) {
+	for _, ch := range c {
+		update(ch.id, ch.total)
+	}
+}
+
+// container is the UnreadContainer returned by the tree for a single server.
+type container struct {
+	tree *Tree
+	id   cchat.ID
+}
+
+var (
+	_ cchat.UnreadContainer      = (*container)(nil)
+	_ cchat.UnreadCountContainer = (*container)(nil)
+)
+
+// SetUnread sets the unread state for backends that don't give counts. An
+// unread server counts as at least one unread message, and a mentioned server
+// counts as at least one mention. Existing counts are kept if the server is
+// still unread.
+func (c container) SetUnread(_ context.Context, unread, mentioned bool) {
+	c.tree.set(c.id, func(self Counts) Counts {
+		if !unread {
+			return Counts{}
+		}
+
+		if self.Unread == 0 {
+			self.Unread = 1
+		}
+
+		switch {
+		case !mentioned:
+			self.Mentions = 0
+		case self.Mentions == 0:
+			self.Mentions = 1
+		}
+
+		return self
+	})
+}
+
+// SetUnreadCount sets the exact counts of the server.
+func (c container) SetUnreadCount(_ context.Context, unread, mentions int) {
+	c.tree.set(c.id, func(Counts) Counts {
+		return Counts{unread, mentions}
+	})
+}
+
+func (c container) AsUnreadCountContainer() cchat.UnreadCountContainer {
+	return c
+}
@@ -0,0 +1,98 @@
+package unread
+
+import (
+	"context"
+	"testing"
+
+	"github.com/diamondburned/cchat"
+	"github.com/go-test/deep"
+)
+
+func TestTree(t *testing.T) {
+	var ctx = context.Background()
+	var updates = map[cchat.ID]Counts{}
+
+	tree := NewTree(func(id cchat.ID, total Counts) { updates[id] = total })
+	tree.AddRoot("session")
+	tree.Add("session", "folder")
+	guild := tree.Add("folder", "guild")
+	channel1 := tree.Add("guild", "channel1")
+	channel2 := tree.Add("guild", "channel2")
+
+	channel1.AsUnreadCountContainer().SetUnreadCount(ctx, 12, 3)
+	channel2.SetUnread(ctx, true, false)
+	guild.SetUnread(ctx, true, false)
+
+	var expect = map[cchat.ID]Counts{
+		"session":  {14, 3},
+		"folder":   {14, 3},
+		"guild":    {14, 3},
+		"channel1": {12, 3},
+		"channel2": {1, 0},
+	}
+	if eq := deep.Equal(updates, expect); eq != nil {
+		t.Fatal("Unexpected totals:", eq)
+	}
+
+	// Marking a server as read should clear its counts.
+	channel1.SetUnread(ctx, false, false)
+
+	if total := tree.Total("session"); total != (Counts{2, 0}) {
+		t.Fatal("Unexpected session total after read:", total)
+	}
+
+	// Moving the guild out of the folder should move its counts too.
+	tree.Add("session", "guild")
+
+	if total := tree.Total("folder"); !total.IsZero() {
+		t.Fatal("Unexpected folder total after move:", total)
+	}
+	if total := tree.Total("session"); total != (Counts{2, 0}) {
+		t.Fatal("Unexpected session total after move:", total)
+	}
+
+	tree.Remove("guild")
+
+	if total := tree.Total("session"); !total.IsZero() {
+		t.Fatal("Unexpected session total after remove:", total)
+	}
+	if total := tree.Total("channel2"); !total.IsZero() {
+		t.Fatal("Removed child still has counts:", total)
+	}
+
+	// Calls to a removed server's container are ignored.
+	channel2.SetUnread(ctx, true, true)
+
+	if total := tree.Total("session"); !total.IsZero() {
+		t.Fatal("Unexpected session total after removed update:", total)
+	}
+}
+
+func TestTreeCycle(t *testing.T) {
+	var ctx = context.Background()
+
+	tree := NewTree(nil)
+	a := tree.Add("root", "a")
+	tree.Add("a", "b")
+	c := tree.Add("b", "c")
+
+	// Moving a server under its descendant or itself must be ignored.
+	tree.Add("c", "a")
+	tree.Add("a", "a")
+
+	a.SetUnread(ctx, true, false)
+	c.SetUnread(ctx, true, true)
+
+	if total := tree.Total("root"); total != (Counts{2, 1}) {
+		t.Fatal("Unexpected root total:", total)
+	}
+	if total := tree.Total("c"); total != (Counts{1, 1}) {
+		t.Fatal("Unexpected c total:", total)
+	}
+
+	tree.Remove("root")
+
+	if total := tree.Total("c"); !total.IsZero() {
+		t.Fatal("Removed descendant still has counts:", total)
+	}
+}